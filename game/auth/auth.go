@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+/**
+
+Room tokens are HMAC signed JWTs that let a client join one room.
+
+They are issued by whoever shares the key with the game server, and are verified before
+the signaling WebSocket is opened. The subject of the token is the client id.
+
+*/
+
+var ErrNoToken = errors.New("no token")
+
+// The claims carried by a room token
+type Claims struct {
+	RoomID string `json:"room_id"`
+	GameID string `json:"game_id"`
+	Name   string `json:"name"` // display name of the client
+	Role   string `json:"role"` // "player", "spectator", or empty to let the client choose
+	jwt.RegisteredClaims
+}
+
+// Sign a room token with the shared key
+func Sign(claims *Claims, key []byte) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+}
+
+// Verify a room token and return its claims
+//
+// Tokens without an expiry or a subject are refused
+func Verify(token string, key []byte) (*Claims, error) {
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %s", t.Header["alg"])
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("token has no expiry")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	return claims, nil
+}
+
+// The room token of an HTTP request
+//
+// Browsers cannot set headers on a WebSocket request, so the token may also be
+// passed in the "token" query parameter
+func FromRequest(req *http.Request) (string, error) {
+
+	if header := req.Header.Get("Authorization"); header != "" {
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == header {
+			return "", errors.New("expected a bearer token")
+		}
+		return token, nil
+	}
+
+	if token := req.URL.Query().Get("token"); token != "" {
+		return token, nil
+	}
+
+	return "", ErrNoToken
+}
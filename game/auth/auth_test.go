@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var testKey = []byte("test key")
+
+func testClaims() *Claims {
+	return &Claims{
+		RoomID: "room",
+		GameID: "TestGame",
+		Name:   "name",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "0123456789abcdef0123456789abcdef",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func TestVerify(t *testing.T) {
+
+	token, err := Sign(testClaims(), testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := Verify(token, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.RoomID != "room" || claims.GameID != "TestGame" || claims.Name != "name" || claims.Subject != "0123456789abcdef0123456789abcdef" {
+		t.Errorf("verified claims %+v, want those signed", claims)
+	}
+
+	expired := testClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := testClaims()
+	noExpiry.ExpiresAt = nil
+	noSubject := testClaims()
+	noSubject.Subject = ""
+
+	tests := []struct {
+		name   string
+		claims *Claims
+		key    []byte
+	}{
+		{"wrong key", testClaims(), []byte("other key")},
+		{"expired", expired, testKey},
+		{"no expiry", noExpiry, testKey},
+		{"no subject", noSubject, testKey},
+	}
+	for _, test := range tests {
+		token, err := Sign(test.claims, test.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Verify(token, testKey); err == nil {
+			t.Errorf("%s: want an error", test.name)
+		}
+	}
+
+	// Unsigned, and signed with a method other than HMAC
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, testClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(none, testKey); err == nil {
+		t.Error("alg none: want an error")
+	}
+	if _, err := Verify(token[:len(token)-2], testKey); err == nil {
+		t.Error("truncated signature: want an error")
+	}
+}
+
+func TestFromRequest(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		url    string
+		header string
+		token  string
+		err    bool
+	}{
+		{"header", "/", "Bearer abc", "abc", false},
+		{"query", "/?token=abc", "", "abc", false},
+		{"header first", "/?token=query", "Bearer header", "header", false},
+		{"not bearer", "/", "Basic abc", "", true},
+		{"none", "/", "", "", true},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", test.url, nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+		token, err := FromRequest(req)
+		if token != test.token || (err != nil) != test.err {
+			t.Errorf("%s: %q, %v", test.name, token, err)
+		}
+	}
+
+	if _, err := FromRequest(httptest.NewRequest("GET", "/", nil)); err != ErrNoToken {
+		t.Errorf("no token: %v, want ErrNoToken", err)
+	}
+}
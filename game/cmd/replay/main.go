@@ -0,0 +1,161 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"go.uber.org/zap"
+
+	"zoomgaming/game"
+	"zoomgaming/inputlog"
+	"zoomgaming/logging"
+	pb "zoomgaming/proto"
+)
+
+// Replay a room's input log into its game, e.g. on a test display, to see what the players saw
+//
+//	go run ./cmd/replay -log inputs/1111/20210401-120000.000.inputs -slot 1 -speed 2
+//
+// The game is started on the display of the room slot, :99 for slot 0, :98 for slot 1 and so on,
+// and each input is injected at its original time, divided by the speed.
+// When the host switched games during the session, the replay switches games at the same point.
+
+var logFile = flag.String("log", "", "input log to replay")
+var slot = flag.Int("slot", 0, "room slot whose display the game is started on")
+var speed = flag.Float64("speed", 1, "how many times faster than the original to replay")
+var linger = flag.Duration("linger", 10*time.Second, "how long to leave the game running after the last input")
+
+func main() {
+
+	flag.Parse()
+
+	if *logFile == "" || *speed <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := replay(logging.L()); err != nil {
+		logging.L().Fatal("Error replaying input log", zap.Error(err))
+	}
+}
+
+func replay(log *zap.Logger) error {
+
+	f, err := os.Open(*logFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	r := &replayer{slot: *slot, log: log}
+	defer r.stop()
+
+	reader := inputlog.NewReader(f)
+	start := time.Now()
+	entries := 0
+
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		switch rec := record.GetRecord().(type) {
+		case *pb.InputLogRecord_Header:
+			if err := r.switchGame(rec.Header.GetGameId()); err != nil {
+				return err
+			}
+			log.Info("Replaying", logging.Room(rec.Header.GetRoomId()), logging.Game(rec.Header.GetGameId()),
+				zap.Time("recorded_at", rec.Header.GetAt().AsTime()))
+
+		case *pb.InputLogRecord_Entry:
+			at := start.Add(time.Duration(float64(rec.Entry.GetOffsetUs()) * float64(time.Microsecond) / *speed))
+			select {
+			case <-time.After(time.Until(at)):
+			case <-interrupt:
+				return nil
+			}
+			r.inject(game.PlayerIndex(rec.Entry.GetSeat()), rec.Entry.GetEvent())
+			entries++
+		}
+	}
+
+	log.Info("Replayed input log", zap.Int("inputs", entries), zap.Duration("length", time.Since(start)))
+
+	select {
+	case <-time.After(*linger):
+	case <-interrupt:
+	}
+	return nil
+}
+
+// Runs the game of the log and feeds it input, seat by seat
+type replayer struct {
+	slot   int
+	typ    game.GameType
+	game   game.Game
+	inputs map[game.PlayerIndex](chan game.Input)
+	log    *zap.Logger
+}
+
+func (r *replayer) switchGame(game_id string) error {
+
+	typ := game.GameTypeOf(game_id)
+	if typ == game.GameUndefined {
+		return fmt.Errorf("unknown game: %s", game_id)
+	}
+	if typ == r.typ {
+		return nil
+	}
+	r.stop()
+
+	g, err := game.NewGame(typ, r.slot, r.log)
+	if err != nil {
+		return err
+	}
+
+	r.typ = typ
+	r.game = g
+	r.inputs = make(map[game.PlayerIndex](chan game.Input))
+	for idx := game.Player1; int(idx) <= typ.Seats(); idx++ {
+		r.inputs[idx] = make(chan game.Input, 1024)
+		if err := g.AttachInputStream(r.inputs[idx], idx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *replayer) inject(idx game.PlayerIndex, evt *pb.InputEvent) {
+
+	ch, prs := r.inputs[idx]
+	if !prs {
+		r.log.Warn("Input for a seat the game does not have", logging.Seat(int(idx)))
+		return
+	}
+
+	ch <- game.Input{Message: evt, ReceivedAt: time.Now()}
+}
+
+func (r *replayer) stop() {
+
+	if r.game == nil {
+		return
+	}
+	for _, ch := range r.inputs {
+		close(ch)
+	}
+	r.game.Stop()
+	r.game = nil
+	r.typ = game.GameUndefined
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+
+	"zoomgaming/auth"
+)
+
+// Issue a room token, e.g. for local development
+//
+//	go run ./cmd/token -room 1111 -game SpaceTime -name alice
+
+var key = flag.String("key", os.Getenv("ZOOMGAMING_TOKEN_KEY"), "key that room tokens are signed with")
+var roomID = flag.String("room", "", "room id")
+var gameID = flag.String("game", "", "game id")
+var name = flag.String("name", "", "display name")
+var role = flag.String("role", "", "player, spectator, or empty for either")
+var subject = flag.String("client", "", "client id, made up if empty")
+var ttl = flag.Duration("ttl", time.Hour, "how long the token is valid for")
+
+func main() {
+
+	flag.Parse()
+
+	if *key == "" || *roomID == "" || *gameID == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *subject == "" {
+		*subject = uuid.New().String()
+	}
+
+	token, err := auth.Sign(&auth.Claims{
+		RoomID: *roomID,
+		GameID: *gameID,
+		Name:   *name,
+		Role:   *role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   *subject,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(*ttl)),
+		},
+	}, []byte(*key))
+	if err != nil {
+		log.Fatalf("signing token: %s", err)
+	}
+
+	fmt.Println(token)
+}
@@ -4,23 +4,36 @@ import (
 	"errors"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
 	"zoomgaming/game"
+	"zoomgaming/logging"
+	"zoomgaming/metrics"
+	pb "zoomgaming/proto"
 	"zoomgaming/room"
+	rtc "zoomgaming/webrtc"
 	ws "zoomgaming/websocket"
 )
 
 type RoomCoordinator interface {
-	JoinRoom(string, string, ws.WebSocket) error
+	JoinRoom(string, string, ws.WebSocket, room.JoinRequest) error
+	CreateRoom(string, string, string, *game.EncodeProfile, bool) error // room id, game id, client id of the host, encode profile, nil for the game's, and whether to stream over HLS
+	Room(string) room.Room                                              // the room with an id, nil if there is none
+	prometheus.Collector                                                // gauges of the rooms and their members
 }
 
+var ErrRoomExists = errors.New("room exists")
+
 type roomCoordinator struct {
 	rooms    map[string](room.Room)
 	mu       *sync.Mutex
 	occupancy map[int]bool
 	maxRooms int
+	roomCfg  room.Config // applied to every room the coordinator creates
 }
 
-func NewRoomCoordinator(maxRooms int) (res RoomCoordinator, err error) {
+func NewRoomCoordinator(maxRooms int, roomCfg room.Config) (res RoomCoordinator, err error) {
 
 	occupancy := make(map[int]bool)
 	for i := 0; i < maxRooms; i++ {
@@ -32,56 +45,197 @@ func NewRoomCoordinator(maxRooms int) (res RoomCoordinator, err error) {
 		mu:       &sync.Mutex{},
 		occupancy: occupancy,
 		maxRooms: maxRooms,
+		roomCfg:  roomCfg,
 	}
 
 	res = c
 	return
 }
 
-func (c *roomCoordinator) JoinRoom(room_id string, game_id string, ws ws.WebSocket) error {
+func (c *roomCoordinator) JoinRoom(room_id string, game_id string, ws ws.WebSocket, req room.JoinRequest) (err error) {
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	typ := game.GameTypeOf(game_id)
+	defer func() { countJoin(typ, err) }()
+
 	if typ == game.GameUndefined {
-		return errors.New("game type")
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_GAME, "unknown game: %s", game_id)
 	}
 
-	var r room.Room
-	var prs bool
-	var err error
-
-	r, prs = c.rooms[room_id]
+	r, prs := c.rooms[room_id]
 	if !prs {
-		if len(c.rooms) >= c.maxRooms {
-			return errors.New("max rooms")
+		r, err = c.newRoom(room_id, typ, c.roomCfg, nil)
+		if err != nil {
+			return err
 		}
+	}
 
-		var i int
-		for i = 0; i < c.maxRooms; i++ {
-			filled := c.occupancy[i]
-			if !filled {
-				break
-			}
-		}
+	return r.NewPlayer(ws, req)
+}
 
-		c.occupancy[i] = true
+// Start a room ahead of time, the host will be able to manage it once they join
+//
+// Parts of the profile that are left out are taken from the game's profile.
+// Only rooms created with hls are streamed over HLS, rooms started by a join never are
+func (c *roomCoordinator) CreateRoom(room_id string, game_id string, host string, profile *game.EncodeProfile, hls bool) (err error) {
 
-		r, err = room.NewRoom(typ, i)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	typ := game.GameTypeOf(game_id)
+	defer func() {
 		if err != nil {
-			return err
+			countJoin(typ, err)
 		}
+	}()
+
+	if typ == game.GameUndefined {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_GAME, "unknown game: %s", game_id)
+	}
+
+	if _, prs := c.rooms[room_id]; prs {
+		return ErrRoomExists
+	}
+
+	cfg := c.roomCfg
+	cfg.Host = host
+	cfg.HLS = hls
+
+	_, err = c.newRoom(room_id, typ, cfg, profile)
+	return err
+}
+
+func (c *roomCoordinator) Room(room_id string) room.Room {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rooms[room_id]
+}
+
+// Must hold c.mu
+func (c *roomCoordinator) newRoom(room_id string, typ game.GameType, cfg room.Config, profile *game.EncodeProfile) (room.Room, error) {
 
-		c.rooms[room_id] = r
-		go func(room_id string, i int) {
-			select {
-			case <-r.Done():
-				delete(c.rooms, room_id)
-				c.occupancy[i] = false
+	if len(c.rooms) >= c.maxRooms {
+		return nil, rtc.NewSignalingError(pb.SignalingError_CODE_MAX_ROOMS, "max rooms: %d", c.maxRooms)
+	}
+
+	var i int
+	for i = 0; i < c.maxRooms; i++ {
+		filled := c.occupancy[i]
+		if !filled {
+			break
+		}
+	}
+
+	cfg.Profile = typ.Profile()
+	if profile != nil {
+		cfg.Profile = profile.Or(cfg.Profile)
+	}
+	if err := checkProfile(cfg.Profile, typ, i); err != nil {
+		return nil, err
+	}
+
+	cfg.ID = room_id
+	r, err := room.NewRoom(typ, i, cfg)
+	if err != nil {
+		logging.L().Error("Error opening room", logging.Room(room_id), logging.Game(typ.String()), zap.Error(err))
+		return nil, err
+	}
+	logging.L().Info("Opened room", logging.Room(room_id), logging.Game(typ.String()), zap.Int("display", 99-i))
+
+	c.occupancy[i] = true
+	c.rooms[room_id] = r
+	go func(room_id string, i int) {
+		select {
+		case <-r.Done():
+			c.mu.Lock()
+			delete(c.rooms, room_id)
+			c.occupancy[i] = false
+			c.mu.Unlock()
+			logging.L().Info("Closed room", logging.Room(room_id))
+		}
+	}(room_id, i)
+
+	return r, nil
+}
+
+// Whether a room slot can capture and encode a game with a profile
+//
+// The test game streams a test pattern rather than its display, so any display will do
+func checkProfile(profile game.EncodeProfile, typ game.GameType, i int) error {
+
+	if err := profile.Check(); err != nil {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_PROFILE, "%s", err)
+	}
+	if typ == game.TestGame {
+		return nil
+	}
+	err := profile.CheckDisplay(i)
+	if errors.Is(err, game.ErrDisplayTooSmall) {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_PROFILE, "%s", err)
+	}
+	return err
+}
+
+// Count a join, or why it was refused
+//
+// Joins are labeled by the game that was asked for, which is the room's game unless the host switched games
+func countJoin(typ game.GameType, err error) {
+
+	label := typ.String() // empty for games that do not exist
+
+	var serr *rtc.SignalingError
+	switch {
+	case err == nil:
+		metrics.Joins.WithLabelValues(label).Inc()
+	case errors.As(err, &serr):
+		metrics.Rejects.WithLabelValues(label, serr.Code.String()).Inc()
+	case errors.Is(err, ErrRoomExists):
+		metrics.Rejects.WithLabelValues(label, "ROOM_EXISTS").Inc()
+	default:
+		metrics.Rejects.WithLabelValues(label, "OTHER").Inc()
+	}
+}
+
+func (c *roomCoordinator) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metrics.Rooms
+	ch <- metrics.Players
+	ch <- metrics.Spectators
+	ch <- metrics.FreeDisplays
+}
+
+func (c *roomCoordinator) Collect(ch chan<- prometheus.Metric) {
+
+	c.mu.Lock()
+	rooms := make([]room.Room, 0, len(c.rooms))
+	for _, r := range c.rooms {
+		rooms = append(rooms, r)
+	}
+	c.mu.Unlock()
+
+	counts := make(map[string]([3]float64)) // rooms, players and spectators, by game
+	for _, r := range rooms {
+		stats := r.Stats()
+		count := counts[stats.Game]
+		count[0]++
+		for _, p := range stats.Players {
+			if p.Role == pb.Role_ROLE_PLAYER {
+				count[1]++
+			} else {
+				count[2]++
 			}
-		}(room_id, i)
+		}
+		count[2] += float64(len(stats.Viewers))
+		counts[stats.Game] = count
 	}
 
-	return r.NewPlayer(ws)
+	for game, count := range counts {
+		ch <- prometheus.MustNewConstMetric(metrics.Rooms, prometheus.GaugeValue, count[0], game)
+		ch <- prometheus.MustNewConstMetric(metrics.Players, prometheus.GaugeValue, count[1], game)
+		ch <- prometheus.MustNewConstMetric(metrics.Spectators, prometheus.GaugeValue, count[2], game)
+	}
+	ch <- prometheus.MustNewConstMetric(metrics.FreeDisplays, prometheus.GaugeValue, float64(c.maxRooms-len(rooms)))
 }
@@ -30,6 +30,28 @@ func GameTypeOf(game_id string) GameType {
 	}
 }
 
+// Settings that describe a game independently of the room it runs in
+type GameDefinition struct {
+	Seats   int           // number of players that can control the game at the same time
+	Profile EncodeProfile // how rooms capture and encode the game, unless the room overrides it
+}
+
+var GameDefinitions = map[GameType](GameDefinition){
+	TestGame:  GameDefinition{Seats: 8, Profile: EncodeProfile{Width: 640, Height: 480, Framerate: 30, Bitrate: 1000}},
+	SpaceTime: GameDefinition{Seats: 4, Profile: EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: 2400}},
+	Broforce:  GameDefinition{Seats: 4, Profile: EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: 2400}},
+}
+
+// Number of player seats in the game, Player1 through Player<n>
+func (typ GameType) Seats() int {
+	return GameDefinitions[typ].Seats
+}
+
+// How rooms capture and encode the game by default
+func (typ GameType) Profile() EncodeProfile {
+	return GameDefinitions[typ].Profile
+}
+
 type keysymMapping map[pb.KeyPressEvent_Key](x.Keysym)
 type keycodeMapping map[pb.KeyPressEvent_Key](x.Keycode)
 type gameMapping map[PlayerIndex](keysymMapping)
@@ -40,6 +62,10 @@ var GameMappings = map[GameType](gameMapping){
 		Player2: keysymMapping{},
 		Player3: keysymMapping{},
 		Player4: keysymMapping{},
+		Player5: keysymMapping{},
+		Player6: keysymMapping{},
+		Player7: keysymMapping{},
+		Player8: keysymMapping{},
 	},
 	SpaceTime: gameMapping{
 		Player1: keysymMapping{
@@ -236,7 +262,17 @@ const (
 )
 
 func (typ mediaStreamType) String() string {
-	return [...]string{"", "TestVP8", "TestOpus", "VideoSH", "AudioSH"}[typ]
+	return [...]string{"", "TestH264", "TestOpus", "VideoSH", "AudioSH"}[typ]
+}
+
+// Ticks per second of the stream's rtp timestamps
+func (typ mediaStreamType) clockRate() uint32 {
+	switch typ {
+	case TestH264, VideoSH:
+		return 90000
+	default:
+		return 48000
+	}
 }
 
 type PlayerIndex int
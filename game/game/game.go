@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"os/exec"
 	"os/user"
@@ -12,9 +11,9 @@ import (
 	x "github.com/linuxdeepin/go-x11-client"
 	"github.com/linuxdeepin/go-x11-client/ext/test"
 	"github.com/linuxdeepin/go-x11-client/util/keysyms"
+	"go.uber.org/zap"
 
-	proto "google.golang.org/protobuf/proto"
-
+	"zoomgaming/logging"
 	pb "zoomgaming/proto"
 )
 
@@ -26,7 +25,9 @@ import (
 */
 
 type Game interface {
-	AttachInputStream(<-chan proto.Message, PlayerIndex) error // mux input streams and relay to the game
+	AttachInputStream(<-chan Input, PlayerIndex) error // mux input streams and relay to the game
+	InputStats() map[PlayerIndex](InputStats)          // latency and lost input of each player that sent any
+	Exited() <-chan struct{}                           // closed once the game exits, whether stopped or not
 	Stop()
 }
 
@@ -36,9 +37,12 @@ type game struct {
 	gameExec       *exec.Cmd
 	cancel         context.CancelFunc
 	playerMappings map[PlayerIndex](keycodeMapping)
+	stats          *inputStats
+	exited         chan struct{} // never closed for the test game, which runs no process
+	log            *zap.Logger
 }
 
-func NewGame(typ GameType, roomIndex int) (g Game, err error) {
+func NewGame(typ GameType, roomIndex int, log *zap.Logger) (g Game, err error) {
 
 	var xdisplay *x.Conn
 
@@ -55,12 +59,16 @@ func NewGame(typ GameType, roomIndex int) (g Game, err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	var gameExec *exec.Cmd
-	xdisplay, err = x.NewConnDisplay(fmt.Sprintf(":%d", 99-roomIndex))
-	if err != nil {
-		panic(fmt.Sprintf("Unable to connect to display %d", 99-roomIndex))
-	}
+	var symbols *keysyms.KeySymbols
 
-	symbols := keysyms.NewKeySymbols(xdisplay)
+	// The test game injects no input and streams no display, so it runs without an X server
+	if typ != TestGame {
+		xdisplay, err = x.NewConnDisplay(fmt.Sprintf(":%d", 99-roomIndex))
+		if err != nil {
+			panic(fmt.Sprintf("Unable to connect to display %d", 99-roomIndex))
+		}
+		symbols = keysyms.NewKeySymbols(xdisplay)
+	}
 
 	keysymMappings := GameMappings[typ]
 	keycodeMappings := make(map[PlayerIndex](keycodeMapping), len(keysymMappings))
@@ -97,6 +105,9 @@ func NewGame(typ GameType, roomIndex int) (g Game, err error) {
 		gameExec:       gameExec,
 		cancel:         cancel,
 		playerMappings: keycodeMappings,
+		stats:          newInputStats(typ),
+		exited:         make(chan struct{}),
+		log:            log.With(logging.Game(typ.String())),
 	}
 
 	if game.gameExec.Path != "" {
@@ -104,17 +115,22 @@ func NewGame(typ GameType, roomIndex int) (g Game, err error) {
 		if err != nil {
 			panic("Error starting game")
 		}
-	}
 
-	go func() {
-		gameExec.Wait()
-	}()
+		go func() {
+			gameExec.Wait()
+			close(game.exited)
+		}()
+	}
 
 	go func() {
 		select {
 		case <-ctx.Done():
-			gameExec.Process.Signal(os.Interrupt)
-			game.xdisplay.Close()
+			if gameExec.Process != nil {
+				gameExec.Process.Signal(os.Interrupt)
+			}
+			if game.xdisplay != nil {
+				game.xdisplay.Close()
+			}
 		}
 	}()
 
@@ -122,7 +138,7 @@ func NewGame(typ GameType, roomIndex int) (g Game, err error) {
 	return
 }
 
-func (g *game) AttachInputStream(ch <-chan proto.Message, idx PlayerIndex) error {
+func (g *game) AttachInputStream(ch <-chan Input, idx PlayerIndex) error {
 
 	mapping, prs := g.playerMappings[idx]
 	if !prs {
@@ -131,18 +147,19 @@ func (g *game) AttachInputStream(ch <-chan proto.Message, idx PlayerIndex) error
 
 	if g.typ == TestGame {
 		go func() {
-			for msg := range ch {
-				msg := msg.(*pb.InputEvent)
-				log.Printf("Received msg from player %s: %s", idx, msg)
+			for input := range ch {
+				msg := input.Message.(*pb.InputEvent)
+				g.log.Debug("Received input", logging.Seat(int(idx)), zap.Stringer("msg", msg))
+				g.stats.record(idx, input)
 			}
 		}()
 	} else {
 		go func() {
 			root := g.xdisplay.GetDefaultScreen().Root
-			for msg := range ch {
-				switch t := msg.(type) {
+			for input := range ch {
+				switch t := input.Message.(type) {
 				case *pb.InputEvent:
-					evt := msg.(*pb.InputEvent).GetKeyPressEvent()
+					evt := t.GetKeyPressEvent()
 					key := mapping[evt.GetKey()]
 					switch evt.GetDirection() {
 					case pb.KeyPressEvent_DIRECTION_UP:
@@ -150,12 +167,13 @@ func (g *game) AttachInputStream(ch <-chan proto.Message, idx PlayerIndex) error
 					case pb.KeyPressEvent_DIRECTION_DOWN:
 						test.FakeInput(g.xdisplay, x.KeyPressEventCode, uint8(key), x.CurrentTime, root, 0, 0, 0)
 					default:
-						log.Printf("No direction specified")
+						g.log.Debug("No direction specified", logging.Seat(int(idx)))
 						continue
 					}
 					g.xdisplay.Flush()
+					g.stats.record(idx, input)
 				default:
-					log.Printf("Unexcepted type: %T", t)
+					g.log.Warn("Unexpected input type", logging.Seat(int(idx)), zap.String("type", fmt.Sprintf("%T", t)))
 					continue
 				}
 			}
@@ -165,6 +183,14 @@ func (g *game) AttachInputStream(ch <-chan proto.Message, idx PlayerIndex) error
 	return nil
 }
 
+func (g *game) InputStats() map[PlayerIndex](InputStats) {
+	return g.stats.snapshot()
+}
+
+func (g *game) Exited() <-chan struct{} {
+	return g.exited
+}
+
 // context cancel for the vidoe, audio streams
 func (g *game) Stop() {
 	g.cancel()
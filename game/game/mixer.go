@@ -0,0 +1,131 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"sync"
+
+	"go.uber.org/zap"
+
+	zutils "zoomgaming/utils"
+)
+
+/**
+
+A mixer decodes the game's audio and the players' voices, mixes them, and re-encodes the mix.
+
+Each input is an Opus RTP stream written to the mixer with Write, and is relayed over UDP
+to a gstreamer pipeline. The mix comes back as a Stream of Opus RTP packets.
+
+Inputs that go quiet, e.g. a player that stops talking, are mixed as silence.
+
+*/
+
+// How loud each input is in the mix, 1 leaves an input as it is
+type MixerGains struct {
+	Game  float64
+	Voice float64
+}
+
+type Mixer interface {
+	Stream
+	Write(PlayerIndex, []byte) // relay an rtp packet to the mixer, PlayerUndefined for the game's audio
+}
+
+type mixer struct {
+	*stream
+	inputs map[PlayerIndex](*net.UDPConn)
+}
+
+func NewMixer(roomIndex int, seats int, gains MixerGains, log *zap.Logger) (m Mixer, err error) {
+
+	var inputs = make(map[PlayerIndex](*net.UDPConn))
+
+	defer func() {
+		if r := recover(); r != nil {
+			for _, input := range inputs {
+				input.Close()
+			}
+			err = errors.New(fmt.Sprintf("%s", r))
+			return
+		}
+	}()
+
+	port := 6004 + roomIndex*2
+
+	args := []string{"audiomixer", "name=mix", "latency=50000000"}
+	for idx := PlayerUndefined; int(idx) <= seats; idx++ {
+		gain := gains.Voice
+		if idx == PlayerUndefined {
+			gain = gains.Game
+		}
+		args = append(args, fmt.Sprintf("sink_%d::volume=%f", idx, gain))
+	}
+	args = append(args, "!", "audioconvert", "!", "opusenc", "bitrate=64000", "!", "rtpopuspay", "!",
+		"udpsink", "host=127.0.0.1", fmt.Sprintf("port=%d", port))
+
+	for idx := PlayerUndefined; int(idx) <= seats; idx++ {
+		inputPort := 7004 + roomIndex*16 + int(idx)
+
+		input, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: inputPort})
+		zutils.PanicOnError(err, "Error dialing mixer input on port %d: %s", inputPort)
+		inputs[idx] = input
+
+		args = append(args, "udpsrc", fmt.Sprintf("port=%d", inputPort),
+			"caps=application/x-rtp,media=audio,clock-rate=48000,encoding-name=OPUS", "!",
+			"rtpjitterbuffer", "latency=50", "!", "rtpopusdepay", "!", "opusdec", "plc=true", "!",
+			"audioconvert", "!", "audioresample", "!", fmt.Sprintf("mix.sink_%d", idx))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(ctx, "gst-launch-1.0", args...)
+
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: port})
+	if err != nil {
+		cancel()
+		panic(fmt.Sprintf("Error opening listener on port %d: %s", port, err))
+	}
+
+	mmixer := &mixer{
+		stream: &stream{
+			listener: listener,
+			cmd:      cmd,
+			exited:   make(chan struct{}),
+			updates:  make(chan (<-chan []byte)),
+			cancel:   cancel,
+			log:      log.With(zap.String("stream", "Mixer")),
+			mu:       &sync.Mutex{},
+		},
+		inputs: inputs,
+	}
+
+	go mmixer.readPackets()
+
+	err = cmd.Start()
+	if err != nil {
+		listener.Close()
+		close(mmixer.exited)
+		panic(err.Error())
+	}
+	go mmixer.wait()
+
+	m = mmixer
+	return
+}
+
+// Inputs that the mixer does not have, e.g. seats beyond those it was created with, are ignored
+func (m *mixer) Write(idx PlayerIndex, pckt []byte) {
+	if input, prs := m.inputs[idx]; prs {
+		input.Write(pckt)
+	}
+}
+
+func (m *mixer) Stop() {
+	m.stream.Stop()
+	for _, input := range m.inputs {
+		input.Close()
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	x "github.com/linuxdeepin/go-x11-client"
+
+	pb "zoomgaming/proto"
+)
+
+/**
+
+An encode profile is how a room's display is captured and encoded: its size, framerate and bitrate
+
+Each game has a profile in its definition, which a room may override when it is created.
+The profile is fixed for the life of the room, since the encoders are not restarted when the host switches games.
+
+*/
+
+const (
+	MaxFramerate = 60
+	MaxBitrate   = 20000 // kbit/s
+)
+
+var ErrDisplayTooSmall = errors.New("display too small")
+
+type EncodeProfile struct {
+	Width     int `json:"width"`
+	Height    int `json:"height"`
+	Framerate int `json:"framerate"`
+	Bitrate   int `json:"bitrate"` // kbit/s
+}
+
+func (p EncodeProfile) String() string {
+	return fmt.Sprintf("%dx%d@%d/%dk", p.Width, p.Height, p.Framerate, p.Bitrate)
+}
+
+// The profile, with anything it leaves out taken from a default
+func (p EncodeProfile) Or(def EncodeProfile) EncodeProfile {
+	if p.Width == 0 && p.Height == 0 {
+		p.Width, p.Height = def.Width, def.Height
+	}
+	if p.Framerate == 0 {
+		p.Framerate = def.Framerate
+	}
+	if p.Bitrate == 0 {
+		p.Bitrate = def.Bitrate
+	}
+	return p
+}
+
+// Whether the encoders can be started with the profile
+func (p EncodeProfile) Check() error {
+	if p.Width <= 0 || p.Height <= 0 || p.Width%2 != 0 || p.Height%2 != 0 {
+		return fmt.Errorf("profile %s must have a positive, even width and height", p)
+	}
+	if p.Framerate <= 0 || p.Framerate > MaxFramerate {
+		return fmt.Errorf("profile %s must have a framerate from 1 to %d", p, MaxFramerate)
+	}
+	if p.Bitrate <= 0 || p.Bitrate > MaxBitrate {
+		return fmt.Errorf("profile %s must have a bitrate from 1k to %dk", p, MaxBitrate)
+	}
+	return nil
+}
+
+// Whether the display of a room slot is large enough to capture the profile, ErrDisplayTooSmall if not
+func (p EncodeProfile) CheckDisplay(roomIndex int) error {
+
+	conn, err := x.NewConnDisplay(fmt.Sprintf(":%d", 99-roomIndex))
+	if err != nil {
+		return fmt.Errorf("Unable to connect to display %d", 99-roomIndex)
+	}
+	defer conn.Close()
+
+	screen := conn.GetDefaultScreen()
+	if p.Width > int(screen.WidthInPixels) || p.Height > int(screen.HeightInPixels) {
+		return fmt.Errorf("%w: profile %s is larger than display %d, %dx%d", ErrDisplayTooSmall, p, 99-roomIndex, screen.WidthInPixels, screen.HeightInPixels)
+	}
+	return nil
+}
+
+func (p EncodeProfile) Proto() *pb.VideoProfile {
+	return &pb.VideoProfile{
+		Width:       uint32(p.Width),
+		Height:      uint32(p.Height),
+		Framerate:   uint32(p.Framerate),
+		BitrateKbps: uint32(p.Bitrate),
+	}
+}
@@ -0,0 +1,51 @@
+package game
+
+import "testing"
+
+func TestEncodeProfileCheck(t *testing.T) {
+
+	tests := []struct {
+		profile EncodeProfile
+		ok      bool
+	}{
+		{EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: 2400}, true},
+		{EncodeProfile{Width: 2, Height: 2, Framerate: 1, Bitrate: 1}, true},
+		{EncodeProfile{Width: 1920, Height: 1080, Framerate: MaxFramerate, Bitrate: MaxBitrate}, true},
+		{EncodeProfile{Width: 0, Height: 720, Framerate: 60, Bitrate: 2400}, false},
+		{EncodeProfile{Width: 1280, Height: -720, Framerate: 60, Bitrate: 2400}, false},
+		{EncodeProfile{Width: 1281, Height: 720, Framerate: 60, Bitrate: 2400}, false},
+		{EncodeProfile{Width: 1280, Height: 721, Framerate: 60, Bitrate: 2400}, false},
+		{EncodeProfile{Width: 1280, Height: 720, Framerate: 0, Bitrate: 2400}, false},
+		{EncodeProfile{Width: 1280, Height: 720, Framerate: MaxFramerate + 1, Bitrate: 2400}, false},
+		{EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: 0}, false},
+		{EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: MaxBitrate + 1}, false},
+	}
+
+	for _, test := range tests {
+		if err := test.profile.Check(); (err == nil) != test.ok {
+			t.Errorf("%s: %v", test.profile, err)
+		}
+	}
+}
+
+func TestEncodeProfileOr(t *testing.T) {
+
+	def := EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: 2400}
+
+	tests := []struct {
+		profile EncodeProfile
+		want    EncodeProfile
+	}{
+		{EncodeProfile{}, def},
+		{EncodeProfile{Width: 640, Height: 480}, EncodeProfile{Width: 640, Height: 480, Framerate: 60, Bitrate: 2400}},
+		{EncodeProfile{Framerate: 30, Bitrate: 1000}, EncodeProfile{Width: 1280, Height: 720, Framerate: 30, Bitrate: 1000}},
+		// A size is taken whole, so half of one fails the check rather than mixing with the default
+		{EncodeProfile{Width: 640}, EncodeProfile{Width: 640, Height: 0, Framerate: 60, Bitrate: 2400}},
+	}
+
+	for _, test := range tests {
+		if got := test.profile.Or(def); got != test.want {
+			t.Errorf("%s or %s = %s, want %s", test.profile, def, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+/**
+
+Renditions are extra, smaller encodes of a room's display, for peers that cannot take the full stream
+
+Each rendition is its own encoder, capturing the same display as the video stream, with the room's
+encode profile, and scaling it down. Renditions send a keyframe every 2 seconds, so that peers
+switching to them do not wait long.
+
+*/
+
+const MaxRenditions = 4 // per room, each takes 2 ports from the room's block of ports
+
+// The size and bitrate of a rendition, e.g. 640x360@800k
+type Rendition struct {
+	Width   int
+	Height  int
+	Bitrate int // kbit/s
+}
+
+func (r Rendition) String() string {
+	return fmt.Sprintf("%dx%d@%dk", r.Width, r.Height, r.Bitrate)
+}
+
+// Whether the rendition is smaller than the full video stream of a profile
+func (r Rendition) Fits(profile EncodeProfile) bool {
+	return r.Width <= profile.Width && r.Height <= profile.Height && r.Bitrate < profile.Bitrate
+}
+
+func ParseRendition(s string) (Rendition, error) {
+
+	var r Rendition
+	size, bitrate := s, ""
+	if i := strings.Index(s, "@"); i >= 0 {
+		size, bitrate = s[:i], s[i+1:]
+	}
+
+	dims := strings.Split(size, "x")
+	if len(dims) != 2 || bitrate == "" {
+		return r, fmt.Errorf("rendition %q is not like 640x360@800k", s)
+	}
+
+	var err error
+	if r.Width, err = strconv.Atoi(dims[0]); err != nil {
+		return r, fmt.Errorf("rendition %q has an invalid width", s)
+	}
+	if r.Height, err = strconv.Atoi(dims[1]); err != nil {
+		return r, fmt.Errorf("rendition %q has an invalid height", s)
+	}
+	if r.Bitrate, err = strconv.Atoi(strings.TrimSuffix(bitrate, "k")); err != nil {
+		return r, fmt.Errorf("rendition %q has an invalid bitrate", s)
+	}
+
+	if r.Width <= 0 || r.Height <= 0 || r.Width%2 != 0 || r.Height%2 != 0 {
+		return r, fmt.Errorf("rendition %q must have a positive, even width and height", s)
+	}
+	if r.Bitrate <= 0 {
+		return r, fmt.Errorf("rendition %q must have a positive bitrate", s)
+	}
+	return r, nil
+}
+
+// Start encoding a rendition of a room's display, layer counts the renditions of the room from 1
+//
+// The test game's renditions are test streams, as its video stream is
+func NewRendition(rend Rendition, profile EncodeProfile, layer int, test bool, roomIndex int, log *zap.Logger) (s Stream, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(fmt.Sprintf("%s", r))
+			return
+		}
+	}()
+
+	if layer < 1 || layer > MaxRenditions {
+		panic(fmt.Sprintf("Invalid rendition layer: %d", layer))
+	}
+
+	if test {
+		s, err = NewTestStream(TestH264, EncodeProfile{Width: rend.Width, Height: rend.Height, Framerate: profile.Framerate, Bitrate: rend.Bitrate}, log.With(zap.Stringer("rendition", rend)))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	port := 11004 + roomIndex*MaxRenditions*2 + (layer-1)*2
+	bitrate := fmt.Sprintf("%dk", rend.Bitrate)
+	gop := fmt.Sprintf("%d", profile.Framerate*2)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-threads", "2", "-filter_threads", "2",
+		"-f", "x11grab", "-draw_mouse", "0", "-s", fmt.Sprintf("%dx%d", profile.Width, profile.Height), "-framerate", fmt.Sprintf("%d", profile.Framerate), "-i", fmt.Sprintf(":%d", 99-roomIndex),
+		"-vf", fmt.Sprintf("scale=%d:%d", rend.Width, rend.Height), "-g", gop,
+		"-b:v", bitrate, "-minrate:v", bitrate, "-maxrate:v", bitrate, "-bufsize:v", bitrate, "-c", "h264_nvenc", "-preset", "p4", "-tune", "ll", "-profile", "high", "-f", "rtp",
+		fmt.Sprintf("rtp://127.0.0.1:%d", port))
+
+	s = startStream(ctx, cancel, cmd, port, 90000, log.With(zap.Stringer("rendition", rend)))
+	return
+}
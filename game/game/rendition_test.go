@@ -0,0 +1,58 @@
+package game
+
+import "testing"
+
+func TestParseRendition(t *testing.T) {
+
+	tests := []struct {
+		s    string
+		want Rendition
+		err  bool
+	}{
+		{"640x360@800k", Rendition{Width: 640, Height: 360, Bitrate: 800}, false},
+		{"1280x720@2000", Rendition{Width: 1280, Height: 720, Bitrate: 2000}, false},
+		{"640x360", Rendition{}, true},
+		{"640@800k", Rendition{}, true},
+		{"640x360x2@800k", Rendition{}, true},
+		{"wide x360@800k", Rendition{}, true},
+		{"640xhigh@800k", Rendition{}, true},
+		{"640x360@fast", Rendition{}, true},
+		{"641x360@800k", Rendition{}, true},
+		{"640x0@800k", Rendition{}, true},
+		{"-640x360@800k", Rendition{}, true},
+		{"640x360@0k", Rendition{}, true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseRendition(test.s)
+		if (err != nil) != test.err {
+			t.Errorf("ParseRendition(%q): %v", test.s, err)
+			continue
+		}
+		if !test.err && got != test.want {
+			t.Errorf("ParseRendition(%q) = %s, want %s", test.s, got, test.want)
+		}
+	}
+}
+
+func TestRenditionFits(t *testing.T) {
+
+	profile := EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: 2400}
+
+	tests := []struct {
+		rend Rendition
+		want bool
+	}{
+		{Rendition{Width: 640, Height: 360, Bitrate: 800}, true},
+		{Rendition{Width: 1280, Height: 720, Bitrate: 1200}, true},
+		{Rendition{Width: 1280, Height: 720, Bitrate: 2400}, false},
+		{Rendition{Width: 1920, Height: 360, Bitrate: 800}, false},
+		{Rendition{Width: 640, Height: 1080, Bitrate: 800}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.rend.Fits(profile); got != test.want {
+			t.Errorf("%s fits %s: %t, want %t", test.rend, profile, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"os/exec"
+	"time"
+)
+
+/**
+
+A still of a room's X display, for previews in the lobby
+
+Each snapshot grabs a single frame with ffmpeg, at the size of the room's encode profile,
+and encodes it as a JPEG. Rooms without a display, i.e. of the test game, encode the grey
+picture their video shows instead.
+
+*/
+
+const snapshotTimeout = 5 * time.Second
+
+// Grab the display of a room slot as a JPEG
+func Snapshot(profile EncodeProfile, roomIndex int) ([]byte, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-loglevel", "error",
+		"-f", "x11grab", "-draw_mouse", "0", "-s", fmt.Sprintf("%dx%d", profile.Width, profile.Height), "-i", fmt.Sprintf(":%d", 99-roomIndex),
+		"-frames:v", "1", "-q:v", "5", "-f", "image2pipe", "-c:v", "mjpeg", "-")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("Error grabbing display %d: %s: %s", 99-roomIndex, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("Error grabbing display %d: no frame", 99-roomIndex)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// A still of the test game, whose video is a flat grey picture, encoded without ffmpeg
+func TestSnapshot(profile EncodeProfile) ([]byte, error) {
+
+	img := image.NewGray(image.Rect(0, 0, profile.Width, profile.Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Gray{Y: 128}), image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		return nil, fmt.Errorf("Error encoding snapshot: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
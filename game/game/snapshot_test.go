@@ -0,0 +1,30 @@
+package game
+
+import (
+	"bytes"
+	"image/jpeg"
+	"testing"
+)
+
+func TestTestSnapshot(t *testing.T) {
+
+	profile := EncodeProfile{Width: 320, Height: 240, Framerate: 30, Bitrate: 500}
+	snapshot, err := TestSnapshot(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(snapshot))
+	if err != nil {
+		t.Fatalf("snapshot is not a JPEG: %s", err)
+	}
+	if b := img.Bounds(); b.Dx() != profile.Width || b.Dy() != profile.Height {
+		t.Errorf("snapshot is %dx%d, want the profile's %dx%d", b.Dx(), b.Dy(), profile.Width, profile.Height)
+	}
+
+	// The same grey as the test game's video
+	r, g, b, _ := img.At(profile.Width/2, profile.Height/2).RGBA()
+	if r>>8 != 128 || g>>8 != 128 || b>>8 != 128 {
+		t.Errorf("snapshot is %d,%d,%d, want grey 128", r>>8, g>>8, b>>8)
+	}
+}
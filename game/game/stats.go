@@ -0,0 +1,147 @@
+package game
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	proto "google.golang.org/protobuf/proto"
+
+	"zoomgaming/metrics"
+	pb "zoomgaming/proto"
+)
+
+/**
+
+Input statistics, kept per player by the game
+
+Latency is measured from when the server received an input to when it was injected into the game.
+Gaps count the sequence numbers that never arrived on a player's input stream.
+
+*/
+
+// An input message and when the server received it
+type Input struct {
+	Message    proto.Message
+	ReceivedAt time.Time
+	Synthetic  bool // made up by the server, e.g. to release a seat's keys, rather than sent by a player
+}
+
+// Upper bounds of the latency buckets, a last bucket holds everything slower
+var latencyBounds = []time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+// Counts of observed durations, by bucket
+type Histogram struct {
+	Bounds []time.Duration // upper bound of each bucket but the last
+	Counts []uint64        // observations in each bucket, one more than there are bounds
+	Count  uint64
+	Sum    time.Duration
+}
+
+func newHistogram(bounds []time.Duration) Histogram {
+	return Histogram{
+		Bounds: bounds,
+		Counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+func (h *Histogram) Observe(d time.Duration) {
+
+	i := 0
+	for i < len(h.Bounds) && d > h.Bounds[i] {
+		i++
+	}
+
+	h.Counts[i]++
+	h.Count++
+	h.Sum += d
+}
+
+func (h Histogram) copy() Histogram {
+	h.Counts = append([]uint64(nil), h.Counts...)
+	return h
+}
+
+// A snapshot of one player's input
+type InputStats struct {
+	Received uint64 // input messages injected into the game
+	Gaps     uint64 // sequence numbers skipped over, i.e. input that was lost or dropped
+	Latency  Histogram
+}
+
+type inputStats struct {
+	mu           *sync.Mutex
+	players      map[PlayerIndex](*InputStats)
+	lastSequence map[PlayerIndex](uint64)
+	latency      prometheus.Observer // the game's input latency metric, across every player
+}
+
+func newInputStats(typ GameType) *inputStats {
+	return &inputStats{
+		mu:           &sync.Mutex{},
+		players:      make(map[PlayerIndex](*InputStats)),
+		lastSequence: make(map[PlayerIndex](uint64)),
+		latency:      metrics.InputLatency.WithLabelValues(typ.String()),
+	}
+}
+
+// Record an input once it has been injected into the game
+//
+// Synthetic input is left out, it says nothing about the player's latency or lost input
+func (s *inputStats) record(idx PlayerIndex, input Input) {
+
+	if input.Synthetic {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, prs := s.players[idx]
+	if !prs {
+		stats = &InputStats{Latency: newHistogram(latencyBounds)}
+		s.players[idx] = stats
+	}
+
+	latency := time.Since(input.ReceivedAt)
+	stats.Received++
+	stats.Latency.Observe(latency)
+	s.latency.Observe(latency.Seconds())
+
+	// Sequences restart whenever another connection takes the seat, only count numbers skipped going forward
+	if evt, ok := input.Message.(*pb.InputEvent); ok && evt.GetSequence() != 0 {
+		seq := evt.GetSequence()
+		if last := s.lastSequence[idx]; last != 0 && seq > last+1 {
+			stats.Gaps += seq - last - 1
+		}
+		s.lastSequence[idx] = seq
+	}
+}
+
+func (s *inputStats) snapshot() map[PlayerIndex](InputStats) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make(map[PlayerIndex](InputStats), len(s.players))
+	for idx, stats := range s.players {
+		snapshot[idx] = InputStats{
+			Received: stats.Received,
+			Gaps:     stats.Gaps,
+			Latency:  stats.Latency.copy(),
+		}
+	}
+
+	return snapshot
+}
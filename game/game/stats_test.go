@@ -0,0 +1,30 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	pb "zoomgaming/proto"
+)
+
+func TestInputStatsRecord(t *testing.T) {
+
+	s := newInputStats(TestGame)
+	for _, input := range []Input{
+		{Message: &pb.InputEvent{Sequence: 1}, ReceivedAt: time.Now()},
+		{Message: &pb.InputEvent{Sequence: 4}, ReceivedAt: time.Now()},
+		{Message: &pb.InputEvent{}, ReceivedAt: time.Now(), Synthetic: true},
+		{Message: &pb.InputEvent{Sequence: 5}, ReceivedAt: time.Now()},
+	} {
+		s.record(Player1, input)
+	}
+	s.record(Player2, Input{Message: &pb.InputEvent{}, ReceivedAt: time.Now(), Synthetic: true})
+
+	stats := s.snapshot()
+	if got := stats[Player1]; got.Received != 3 || got.Gaps != 2 || got.Latency.Count != 3 {
+		t.Errorf("player 1 has %d received, %d gaps and %d latencies, want 3, 2 and 3", got.Received, got.Gaps, got.Latency.Count)
+	}
+	if _, prs := stats[Player2]; prs {
+		t.Error("player 2 has stats for synthetic input only")
+	}
+}
@@ -3,11 +3,17 @@ package game
 import (
 	_ "bufio"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
-	"log"
 	"net"
 	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/rtcp"
+	"go.uber.org/zap"
 
 	zutils "zoomgaming/utils"
 )
@@ -21,21 +27,42 @@ The Start() method must be called to begin reading from the stream.
 
 No close method; the caller's context will end the stream.
 
+Encoders that send RTCP sender reports to the next port up, as ffmpeg does, map RTP timestamps
+to the wall clock time the media was captured at, which tells how long capturing and encoding take.
+
 */
 
 type Stream interface {
 	Updates() chan (<-chan []byte) // only one channel of rtp packets is expected
+	CaptureDelay() time.Duration   // from capturing the latest packet to reading it, 0 until the encoder sends a sender report
+	Exited() <-chan struct{}       // closed once the encoder exits, whether stopped or not
 	Stop()
 }
 
 type stream struct {
+	delay    int64 // latest capture delay in nanoseconds, accessed atomically
 	listener *net.UDPConn
+	rtcp     *net.UDPConn // sender reports from the encoder, nil if it sends none
 	cmd      *exec.Cmd
+	exited   chan struct{}
 	updates  chan (<-chan []byte)
 	cancel   context.CancelFunc
+	log      *zap.Logger
+
+	clockRate uint32
+	mu        *sync.Mutex // protects the latest sender report
+	srNTP     time.Time
+	srRTP     uint32
 }
 
-func NewStream(typ mediaStreamType, roomIndex int) (s Stream, err error) {
+// Start an encoder, video encoders capture and encode with the profile, audio encoders ignore it
+//
+// The test streams are synthesized in-process rather than encoded, see teststream.go
+func NewStream(typ mediaStreamType, profile EncodeProfile, roomIndex int, log *zap.Logger) (s Stream, err error) {
+
+	if typ == TestH264 || typ == TestOpus {
+		return NewTestStream(typ, profile, log)
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -49,15 +76,17 @@ func NewStream(typ mediaStreamType, roomIndex int) (s Stream, err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	var port int
+	size := fmt.Sprintf("%dx%d", profile.Width, profile.Height)
+	bitrate := fmt.Sprintf("%dk", profile.Bitrate)
 
 	switch typ {
 	case VideoSH:
 		port = 5004 + roomIndex*2
 		cmd = exec.CommandContext(ctx, "ffmpeg", "-hwaccel", "cuda", "-hwaccel_output_format", "cuda", "-threads", "2", "-filter_threads", "2",
-		"-f", "x11grab", "-draw_mouse", "0", "-s", "1280x720", "-framerate", "60", "-i", fmt.Sprintf(":%d", 99-roomIndex),
-		"-b:v", "2400k", "-minrate:v", "2400k", "-maxrate:v", "2400k", "-bufsize:v", "2400k", "-c", "h264_nvenc", "-preset", "p4", "-tune", "ll", "-profile", "high", "-f", "rtp",
+		"-f", "x11grab", "-draw_mouse", "0", "-s", size, "-framerate", fmt.Sprintf("%d", profile.Framerate), "-i", fmt.Sprintf(":%d", 99-roomIndex),
+		"-b:v", bitrate, "-minrate:v", bitrate, "-maxrate:v", bitrate, "-bufsize:v", bitrate, "-c", "h264_nvenc", "-preset", "p4", "-tune", "ll", "-profile", "high", "-f", "rtp",
 		fmt.Sprintf("rtp://127.0.0.1:%d", port))
-		// cmd = exec.CommandContext(ctx, "bash", "./video.sh", fmt.Sprintf(":%d", 99-roomIndex), fmt.Sprintf("%d", port))
+		// cmd = exec.CommandContext(ctx, "bash", "./video.sh", fmt.Sprintf(":%d", 99-roomIndex), fmt.Sprintf("%d", port), size, fmt.Sprintf("%d", profile.Framerate), bitrate)
 		break
 	case AudioSH:
 		port = 4004 + roomIndex*2
@@ -65,33 +94,42 @@ func NewStream(typ mediaStreamType, roomIndex int) (s Stream, err error) {
 		"leaky=1", "max-size-time=16000000", "max-size-buffers=0", "max-size-bytes=0", "!", "udpsink", "host=127.0.0.1", fmt.Sprintf("port=%d", port))
 		// cmd = exec.CommandContext(ctx, "bash", "./audio.sh", fmt.Sprintf("%d", port))
 		break
-	case TestH264:
-		port = 5004 + roomIndex*2
-		cmd = exec.CommandContext(ctx, "ffmpeg", "-re", "-f", "lavfi", "-i", "testsrc=size=640x480:rate=30",
-			"-vcodec", "libx264", "-cpu-used", "5", "-deadline", "1", "-g", "10", "-error-resilient", "1", "-auto-alt-ref", "1", "-f", "rtp",
-			fmt.Sprintf("rtp://127.0.0.1:%d?pkt_size=1200", port))
-		break
-	case TestOpus:
-		port = 4004 + roomIndex*2
-		cmd = exec.CommandContext(ctx, "ffmpeg", "-f", "lavfi", "-i", "sine=frequency=1000",
-			"-c:a", "libopus", "-b:a", "8000", "-sample_fmt", "s16p", "-ssrc", "1", "-payload_type", "111", "-f", "rtp", "-max_delay", "0", "-application", "lowdelay",
-			fmt.Sprintf("rtp://127.0.0.1:%d?pkt_size=1200", port))
-		break
 	default:
 		panic(fmt.Sprintf("Invalid MediaStreamType: %s", typ))
 	}
 
+	s = startStream(ctx, cancel, cmd, port, typ.clockRate(), log.With(zap.Stringer("stream", typ)))
+	return
+}
+
+// Listen for the rtp of an encoder on a port, and its sender reports on the next port up, then start the encoder
+//
+// Panics if the encoder does not start, for the constructors to recover
+func startStream(ctx context.Context, cancel context.CancelFunc, cmd *exec.Cmd, port int, clockRate uint32, log *zap.Logger) *stream {
+
 	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: port})
-	zutils.FailOnError(err, "Error opening listener on port %d: ", port)
+	zutils.PanicOnError(err, "Error opening listener on port %d: %s", port)
+
+	rtcpListener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: port + 1})
+	if err != nil {
+		listener.Close()
+		panic(fmt.Sprintf("Error opening rtcp listener on port %d: %s", port+1, err))
+	}
 
 	sstream := &stream{
-		listener: listener,
-		cmd:      cmd,
-		updates:  make(chan (<-chan []byte)),
-		cancel:   cancel,
+		listener:  listener,
+		rtcp:      rtcpListener,
+		cmd:       cmd,
+		exited:    make(chan struct{}),
+		updates:   make(chan (<-chan []byte)),
+		cancel:    cancel,
+		log:       log,
+		clockRate: clockRate,
+		mu:        &sync.Mutex{},
 	}
 
 	go sstream.readPackets()
+	go sstream.readSenderReports()
 
 	/** DEBUG
 	stdout, err := s.cmd.StdoutPipe()
@@ -106,8 +144,11 @@ func NewStream(typ mediaStreamType, roomIndex int) (s Stream, err error) {
 	err = cmd.Start()
 	if err != nil {
 		listener.Close()
+		rtcpListener.Close()
+		close(sstream.exited)
 		panic(err.Error())
 	}
+	go sstream.wait()
 	/** DEBUG
 	go func() {
 		buf := bufio.NewReader(stdout) // Notice that this is not in a loop
@@ -116,7 +157,7 @@ func NewStream(typ mediaStreamType, roomIndex int) (s Stream, err error) {
 			if string(line) == "" {
 				continue
 			}
-			log.Println(string(line))
+			s.log.Debug(string(line))
 		}
 	}()
 
@@ -127,22 +168,32 @@ func NewStream(typ mediaStreamType, roomIndex int) (s Stream, err error) {
 			if string(line) == "" {
 				continue
 			}
-			log.Println(string(line))
+			s.log.Debug(string(line))
 		}
 	}()
 	*/
 
-	s = sstream
-	return
+	return sstream
 }
 
 func (s *stream) Updates() chan (<-chan []byte) {
 	return s.updates
 }
 
+func (s *stream) CaptureDelay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.delay))
+}
+
+func (s *stream) Exited() <-chan struct{} {
+	return s.exited
+}
+
 func (s *stream) Stop() {
 	s.cancel()
 	s.listener.Close()
+	if s.rtcp != nil {
+		s.rtcp.Close()
+	}
 }
 
 func (s *stream) readPackets() {
@@ -151,7 +202,7 @@ func (s *stream) readPackets() {
 	s.updates <- receiver
 
 	defer func() {
-		s.cmd.Wait()
+		<-s.exited
 		close(receiver)
 		close(s.updates)
 	}()
@@ -162,10 +213,63 @@ func (s *stream) readPackets() {
 		n, _, err := s.listener.ReadFrom(inboundRTPPacket)
 
 		receiver <- inboundRTPPacket[:n]
+		s.measureDelay(inboundRTPPacket[:n])
 
 		if err != nil {
-			log.Printf("UDP Connection closed - exiting: %s", err)
+			s.log.Debug("UDP connection closed, exiting", zap.Error(err))
 			return
 		}
 	}
 }
+
+func (s *stream) wait() {
+	s.cmd.Wait()
+	close(s.exited)
+}
+
+// Keep the latest mapping from rtp timestamps to wall clock time
+func (s *stream) readSenderReports() {
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := s.rtcp.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		pkts, err := rtcp.Unmarshal(buf[:n])
+		if err != nil {
+			continue
+		}
+
+		for _, pkt := range pkts {
+			if sr, ok := pkt.(*rtcp.SenderReport); ok {
+				s.mu.Lock()
+				s.srNTP = zutils.FromNTP(sr.NTPTime)
+				s.srRTP = sr.RTPTime
+				s.mu.Unlock()
+			}
+		}
+	}
+}
+
+// How long ago a packet was captured, going by the latest sender report
+func (s *stream) measureDelay(pckt []byte) {
+
+	if len(pckt) < 12 || s.clockRate == 0 {
+		return
+	}
+	ts := binary.BigEndian.Uint32(pckt[4:8])
+
+	s.mu.Lock()
+	srNTP, srRTP := s.srNTP, s.srRTP
+	s.mu.Unlock()
+
+	if srNTP.IsZero() {
+		return
+	}
+
+	// rtp timestamps wrap around, the difference is signed
+	captured := srNTP.Add(time.Duration(int32(ts-srRTP)) * time.Second / time.Duration(s.clockRate))
+	atomic.StoreInt64(&s.delay, int64(time.Since(captured)))
+}
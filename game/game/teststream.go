@@ -0,0 +1,342 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+	"go.uber.org/zap"
+)
+
+/**
+
+Test streams synthesize RTP in-process, so that the test game and tests run without ffmpeg
+
+The video is a flat grey picture in H264 constrained baseline, at the size and framerate of the profile:
+a keyframe every second, made of SPS, PPS and an IDR slice of DC predicted macroblocks with no residual,
+and P slices that skip every macroblock in between. The NALUs are built once per stream, and decode in
+any browser. The audio is Opus silence, one 20ms frame at a time.
+
+Packets are sent at the pace they would be captured at, so the bitrate is far below the profile's.
+
+*/
+
+const (
+	testMTU        = 1200
+	opusFrame      = 20 * time.Millisecond
+	opusFrameTicks = 48000 / 50
+)
+
+var opusSilence = []byte{0xf8, 0xff, 0xfe} // a 20ms CELT fullband frame of silence
+
+type testStream struct {
+	delay      int64 // since the latest packet was due, in nanoseconds, accessed atomically
+	packetizer rtp.Packetizer
+	frames     [][]byte      // the payload of each frame of a cycle, sent in turn
+	interval   time.Duration // between frames
+	ticks      uint32        // rtp timestamp increment between frames
+	updates    chan (<-chan []byte)
+	exited     chan struct{}
+	once       *sync.Once
+	log        *zap.Logger
+}
+
+// Start a test stream, TestH264 encodes the size and framerate of the profile, TestOpus ignores it
+func NewTestStream(typ mediaStreamType, profile EncodeProfile, log *zap.Logger) (s Stream, err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(fmt.Sprintf("%s", r))
+			return
+		}
+	}()
+
+	ts := &testStream{
+		updates: make(chan (<-chan []byte)),
+		exited:  make(chan struct{}),
+		once:    &sync.Once{},
+		log:     log.With(zap.Stringer("stream", typ)),
+	}
+
+	switch typ {
+	case TestH264:
+		if err := profile.Check(); err != nil {
+			panic(err.Error())
+		}
+		ts.packetizer = rtp.NewPacketizer(testMTU, 96, rand.Uint32(), &codecs.H264Payloader{}, rtp.NewRandomSequencer(), typ.clockRate())
+		ts.frames = h264Cycle(profile)
+		ts.interval = time.Second / time.Duration(profile.Framerate)
+		ts.ticks = typ.clockRate() / uint32(profile.Framerate)
+	case TestOpus:
+		ts.packetizer = rtp.NewPacketizer(testMTU, 111, rand.Uint32(), &codecs.OpusPayloader{}, rtp.NewRandomSequencer(), typ.clockRate())
+		ts.frames = [][]byte{opusSilence}
+		ts.interval = opusFrame
+		ts.ticks = opusFrameTicks
+	default:
+		panic(fmt.Sprintf("Invalid test MediaStreamType: %s", typ))
+	}
+
+	go ts.run()
+
+	s = ts
+	return
+}
+
+func (s *testStream) Updates() chan (<-chan []byte) {
+	return s.updates
+}
+
+func (s *testStream) CaptureDelay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.delay))
+}
+
+func (s *testStream) Exited() <-chan struct{} {
+	return s.exited
+}
+
+func (s *testStream) Stop() {
+	s.once.Do(func() {
+		close(s.exited)
+	})
+}
+
+// Send the frames in turn, one per interval, until the stream is stopped
+func (s *testStream) run() {
+
+	receiver := make(chan []byte, 400)
+	defer func() {
+		close(receiver)
+		close(s.updates)
+	}()
+
+	select {
+	case s.updates <- receiver:
+	case <-s.exited:
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for n := 0; ; n++ {
+		var due time.Time
+		select {
+		case <-s.exited:
+			s.log.Debug("Test stream stopped, exiting")
+			return
+		case due = <-ticker.C:
+		}
+
+		for _, pkt := range s.packetizer.Packetize(s.frames[n%len(s.frames)], s.ticks) {
+			pckt, err := pkt.Marshal()
+			if err != nil {
+				continue
+			}
+			select {
+			case receiver <- pckt:
+			case <-s.exited:
+				return
+			}
+		}
+		atomic.StoreInt64(&s.delay, int64(time.Since(due)))
+	}
+}
+
+// One second of frames in Annex-B, a keyframe then P frames
+//
+// At 1 fps the cycle takes 2 frames, so that keyframes are never adjacent and may share their idr_pic_id
+func h264Cycle(profile EncodeProfile) [][]byte {
+
+	mbWidth := (profile.Width + 15) / 16
+	mbHeight := (profile.Height + 15) / 16
+
+	count := profile.Framerate
+	if count < 2 {
+		count = 2
+	}
+
+	frames := make([][]byte, count)
+	frames[0] = annexB(h264SPS(profile, mbWidth, mbHeight), h264PPS(), h264IDR(mbWidth*mbHeight))
+	for i := 1; i < len(frames); i++ {
+		frames[i] = annexB(h264P(mbWidth*mbHeight, i))
+	}
+	return frames
+}
+
+// Sequence parameter set, constrained baseline with frame_num of 4 bits and pic_order_cnt_type 2
+func h264SPS(profile EncodeProfile, mbWidth int, mbHeight int) []byte {
+
+	level := 31
+	switch mbs := mbWidth * mbHeight; {
+	case mbs > 8704:
+		level = 52
+	case mbs > 3600:
+		level = 42
+	}
+
+	b := &bitWriter{}
+	b.bits(66, 8)   // profile_idc, baseline
+	b.bits(0xe0, 8) // constraint_set0, 1 and 2 flags, constrained baseline
+	b.bits(uint32(level), 8)
+	b.ue(0)      // seq_parameter_set_id
+	b.ue(0)      // log2_max_frame_num_minus4
+	b.ue(2)      // pic_order_cnt_type
+	b.ue(1)      // max_num_ref_frames
+	b.bits(0, 1) // gaps_in_frame_num_value_allowed_flag
+	b.ue(uint32(mbWidth - 1))
+	b.ue(uint32(mbHeight - 1))
+	b.bits(1, 1) // frame_mbs_only_flag
+	b.bits(1, 1) // direct_8x8_inference_flag
+
+	// Crop sizes that are not a whole number of macroblocks, in units of 2 pixels
+	cropRight, cropBottom := (mbWidth*16-profile.Width)/2, (mbHeight*16-profile.Height)/2
+	if cropRight > 0 || cropBottom > 0 {
+		b.bits(1, 1)
+		b.ue(0)
+		b.ue(uint32(cropRight))
+		b.ue(0)
+		b.ue(uint32(cropBottom))
+	} else {
+		b.bits(0, 1)
+	}
+
+	b.bits(0, 1) // vui_parameters_present_flag
+	return b.nalu(3, 7)
+}
+
+// Picture parameter set, CAVLC with no deblocking control
+func h264PPS() []byte {
+
+	b := &bitWriter{}
+	b.ue(0)      // pic_parameter_set_id
+	b.ue(0)      // seq_parameter_set_id
+	b.bits(0, 1) // entropy_coding_mode_flag
+	b.bits(0, 1) // bottom_field_pic_order_in_frame_present_flag
+	b.ue(0)      // num_slice_groups_minus1
+	b.ue(0)      // num_ref_idx_l0_default_active_minus1
+	b.ue(0)      // num_ref_idx_l1_default_active_minus1
+	b.bits(0, 1) // weighted_pred_flag
+	b.bits(0, 2) // weighted_bipred_idc
+	b.se(0)      // pic_init_qp_minus26
+	b.se(0)      // pic_init_qs_minus26
+	b.se(0)      // chroma_qp_index_offset
+	b.bits(0, 1) // deblocking_filter_control_present_flag
+	b.bits(0, 1) // constrained_intra_pred_flag
+	b.bits(0, 1) // redundant_pic_cnt_present_flag
+	return b.nalu(3, 8)
+}
+
+// An IDR slice of every macroblock, each Intra 16x16 DC predicted with no residual, which decodes to grey
+func h264IDR(mbs int) []byte {
+
+	b := &bitWriter{}
+	b.ue(0)      // first_mb_in_slice
+	b.ue(7)      // slice_type, I
+	b.ue(0)      // pic_parameter_set_id
+	b.bits(0, 4) // frame_num
+	b.ue(0)      // idr_pic_id
+	b.bits(0, 1) // no_output_of_prior_pics_flag
+	b.bits(0, 1) // long_term_reference_flag
+	b.se(0)      // slice_qp_delta
+
+	for i := 0; i < mbs; i++ {
+		b.ue(3)      // mb_type, I_16x16_2_0_0
+		b.ue(0)      // intra_chroma_pred_mode, DC
+		b.se(0)      // mb_qp_delta
+		b.bits(1, 1) // coeff_token of the luma DC block, no coefficients
+	}
+	return b.nalu(3, 5)
+}
+
+// A P slice that skips every macroblock, repeating the previous frame
+func h264P(mbs int, frameNum int) []byte {
+
+	b := &bitWriter{}
+	b.ue(0)                        // first_mb_in_slice
+	b.ue(5)                        // slice_type, P
+	b.ue(0)                        // pic_parameter_set_id
+	b.bits(uint32(frameNum%16), 4) // frame_num
+	b.bits(0, 1)                   // num_ref_idx_active_override_flag
+	b.bits(0, 1)                   // ref_pic_list_modification_flag_l0
+	b.bits(0, 1)                   // adaptive_ref_pic_marking_mode_flag
+	b.se(0)                        // slice_qp_delta
+	b.ue(uint32(mbs))              // mb_skip_run
+	return b.nalu(2, 1)
+}
+
+func annexB(nalus ...[]byte) []byte {
+	var out []byte
+	for _, nalu := range nalus {
+		out = append(out, 0, 0, 0, 1)
+		out = append(out, nalu...)
+	}
+	return out
+}
+
+// Writes the bits of a NALU's payload, most significant first
+type bitWriter struct {
+	buf []byte
+	n   uint // bits written
+}
+
+func (b *bitWriter) bits(v uint32, n uint) {
+	for i := int(n) - 1; i >= 0; i-- {
+		if b.n%8 == 0 {
+			b.buf = append(b.buf, 0)
+		}
+		if v>>uint(i)&1 == 1 {
+			b.buf[len(b.buf)-1] |= 0x80 >> (b.n % 8)
+		}
+		b.n++
+	}
+}
+
+// Unsigned Exp-Golomb
+func (b *bitWriter) ue(v uint32) {
+	v++
+	n := uint(0)
+	for x := v; x > 1; x >>= 1 {
+		n++
+	}
+	b.bits(0, n)
+	b.bits(v, n+1)
+}
+
+// Signed Exp-Golomb
+func (b *bitWriter) se(v int32) {
+	if v > 0 {
+		b.ue(uint32(2*v - 1))
+	} else {
+		b.ue(uint32(-2 * v))
+	}
+}
+
+// The NALU of the bits written, with its header, trailing bits and emulation prevention
+func (b *bitWriter) nalu(refIdc byte, typ byte) []byte {
+
+	b.bits(1, 1) // rbsp_stop_one_bit
+	for b.n%8 != 0 {
+		b.bits(0, 1)
+	}
+
+	out := []byte{refIdc<<5 | typ}
+	zeros := 0
+	for _, c := range b.buf {
+		if zeros >= 2 && c <= 3 {
+			out = append(out, 3)
+			zeros = 0
+		}
+		out = append(out, c)
+		if c == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+	return out
+}
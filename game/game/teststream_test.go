@@ -0,0 +1,272 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"go.uber.org/zap"
+)
+
+// Reads back what a bitWriter wrote, after emulation prevention is removed
+type bitReader struct {
+	buf []byte
+	n   uint
+}
+
+func (b *bitReader) bits(n uint) uint32 {
+	var v uint32
+	for i := uint(0); i < n; i++ {
+		v = v<<1 | uint32(b.buf[b.n/8]>>(7-b.n%8)&1)
+		b.n++
+	}
+	return v
+}
+
+func (b *bitReader) ue() uint32 {
+	zeros := uint(0)
+	for b.bits(1) == 0 {
+		zeros++
+	}
+	return 1<<zeros - 1 + b.bits(zeros)
+}
+
+// The RBSP of a NALU, without its header and emulation prevention bytes
+func rbsp(nalu []byte) []byte {
+	var out []byte
+	zeros := 0
+	for _, c := range nalu[1:] {
+		if zeros >= 2 && c == 3 {
+			zeros = 0
+			continue
+		}
+		out = append(out, c)
+		if c == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+	return out
+}
+
+func splitAnnexB(t *testing.T, frame []byte) [][]byte {
+	t.Helper()
+
+	start := []byte{0, 0, 0, 1}
+	if !bytes.HasPrefix(frame, start) {
+		t.Fatalf("frame does not start with a start code: % x", frame[:4])
+	}
+	return bytes.Split(frame[len(start):], start)
+}
+
+func TestH264Cycle(t *testing.T) {
+
+	profile := EncodeProfile{Width: 650, Height: 480, Framerate: 30, Bitrate: 1000}
+	frames := h264Cycle(profile)
+	if len(frames) != profile.Framerate {
+		t.Fatalf("cycle of %d frames, want %d", len(frames), profile.Framerate)
+	}
+
+	nalus := splitAnnexB(t, frames[0])
+	if len(nalus) != 3 {
+		t.Fatalf("keyframe has %d NALUs, want SPS, PPS and IDR", len(nalus))
+	}
+	for i, typ := range []byte{7, 8, 5} {
+		if got := nalus[i][0] & 0x1f; got != typ {
+			t.Errorf("keyframe NALU %d has type %d, want %d", i, got, typ)
+		}
+	}
+
+	// The SPS's size, in macroblocks and cropped to the profile's
+	sps := &bitReader{buf: rbsp(nalus[0])}
+	if profileIdc := sps.bits(8); profileIdc != 66 {
+		t.Errorf("profile_idc %d, want baseline", profileIdc)
+	}
+	sps.bits(16) // constraint flags and level_idc
+	for i := 0; i < 4; i++ {
+		sps.ue() // seq_parameter_set_id to max_num_ref_frames
+	}
+	sps.bits(1)
+	mbWidth, mbHeight := sps.ue()+1, sps.ue()+1
+	if mbWidth != 41 || mbHeight != 30 {
+		t.Errorf("%dx%d macroblocks, want 41x30", mbWidth, mbHeight)
+	}
+	sps.bits(2)
+	if cropping := sps.bits(1); cropping != 1 {
+		t.Fatal("no frame cropping, want a crop to 650 wide")
+	}
+	if left, right := sps.ue(), sps.ue(); left != 0 || right != 3 {
+		t.Errorf("crops %d left and %d right, want 0 and 3", left, right)
+	}
+
+	for i := 1; i < len(frames); i++ {
+		nalus := splitAnnexB(t, frames[i])
+		if len(nalus) != 1 || nalus[0][0]&0x1f != 1 {
+			t.Fatalf("frame %d is not a single P slice", i)
+		}
+
+		slice := &bitReader{buf: rbsp(nalus[0])}
+		slice.ue()
+		if sliceType := slice.ue(); sliceType != 5 {
+			t.Errorf("frame %d has slice_type %d, want P", i, sliceType)
+		}
+		slice.ue()
+		if frameNum := slice.bits(4); frameNum != uint32(i%16) {
+			t.Errorf("frame %d has frame_num %d, want %d", i, frameNum, i%16)
+		}
+	}
+
+	if frames := h264Cycle(EncodeProfile{Width: 640, Height: 480, Framerate: 1, Bitrate: 1000}); len(frames) != 2 {
+		t.Errorf("cycle of %d frames at 1 fps, want 2", len(frames))
+	}
+}
+
+func TestBitWriter(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		write func(*bitWriter)
+		want  []byte // the NALU's payload, after its header
+	}{
+		{"ue(0)", func(b *bitWriter) { b.ue(0) }, []byte{0xc0}},                      // 1, stop bit
+		{"ue(1)", func(b *bitWriter) { b.ue(1) }, []byte{0x50}},                      // 010
+		{"ue(2)", func(b *bitWriter) { b.ue(2) }, []byte{0x70}},                      // 011
+		{"ue(3)", func(b *bitWriter) { b.ue(3) }, []byte{0x24}},                      // 00100
+		{"ue(7)", func(b *bitWriter) { b.ue(7) }, []byte{0x11}},                      // 0001000
+		{"se(1)", func(b *bitWriter) { b.se(1) }, []byte{0x50}},                      // 010
+		{"se(-1)", func(b *bitWriter) { b.se(-1) }, []byte{0x70}},                    // 011
+		{"se(2)", func(b *bitWriter) { b.se(2) }, []byte{0x24}},                      // 00100
+		{"bits", func(b *bitWriter) { b.bits(0xa, 4) }, []byte{0xa8}},                // 1010
+		{"byte aligned", func(b *bitWriter) { b.bits(0x42, 8) }, []byte{0x42, 0x80}}, // stop bit in a byte of its own
+		{"emulation prevention", func(b *bitWriter) { b.bits(0x000001, 24); b.bits(0x0000, 16); b.bits(0x03, 8) },
+			[]byte{0x00, 0x00, 0x03, 0x01, 0x00, 0x00, 0x03, 0x03, 0x80}},
+		{"zeros then a large byte", func(b *bitWriter) { b.bits(0x000004, 24) }, []byte{0x00, 0x00, 0x04, 0x80}},
+	}
+
+	for _, test := range tests {
+		b := &bitWriter{}
+		test.write(b)
+		got := b.nalu(3, 7)
+		if got[0] != 0x67 {
+			t.Errorf("%s: header %#x, want 0x67", test.name, got[0])
+		}
+		if !bytes.Equal(got[1:], test.want) {
+			t.Errorf("%s: % x, want % x", test.name, got[1:], test.want)
+		}
+	}
+}
+
+func TestTestStreamPacing(t *testing.T) {
+
+	tests := []struct {
+		typ      mediaStreamType
+		profile  EncodeProfile
+		interval time.Duration
+		step     uint32
+	}{
+		{TestH264, EncodeProfile{Width: 320, Height: 240, Framerate: 10, Bitrate: 500}, 100 * time.Millisecond, 9000},
+		{TestOpus, EncodeProfile{}, 20 * time.Millisecond, 960},
+	}
+
+	for _, test := range tests {
+		s, err := NewTestStream(test.typ, test.profile, zap.NewNop())
+		if err != nil {
+			t.Fatalf("%s: %s", test.typ, err)
+		}
+		receiver := <-s.Updates()
+
+		// The timestamp of each frame's first packet, and when it arrived
+		const frames = 6
+		var timestamps []uint32
+		var arrivals []time.Time
+		for len(timestamps) < frames {
+			pckt := &rtp.Packet{}
+			select {
+			case b := <-receiver:
+				if err := pckt.Unmarshal(b); err != nil {
+					t.Fatalf("%s: %s", test.typ, err)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("%s: no packet within a second", test.typ)
+			}
+			if n := len(timestamps); n == 0 || pckt.Timestamp != timestamps[n-1] {
+				timestamps = append(timestamps, pckt.Timestamp)
+				arrivals = append(arrivals, time.Now())
+			}
+		}
+		s.Stop()
+
+		for i := 1; i < frames; i++ {
+			if step := timestamps[i] - timestamps[i-1]; step != test.step {
+				t.Errorf("%s: timestamp step %d, want %d", test.typ, step, test.step)
+			}
+		}
+		elapsed, want := arrivals[frames-1].Sub(arrivals[0]), time.Duration(frames-1)*test.interval
+		if elapsed < want*8/10 || elapsed > want*2 {
+			t.Errorf("%s: %d frames in %s, want about %s", test.typ, frames-1, elapsed, want)
+		}
+	}
+}
+
+func TestTestStreamStop(t *testing.T) {
+
+	s, err := NewTestStream(TestH264, EncodeProfile{Width: 320, Height: 240, Framerate: 30, Bitrate: 500}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver := <-s.Updates()
+
+	s.Stop()
+	s.Stop() // stopping twice is harmless
+
+	select {
+	case <-s.Exited():
+	default:
+		t.Error("Exited is open after Stop")
+	}
+
+	timeout := time.After(time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-s.Updates():
+			closed = !ok
+		case <-timeout:
+			t.Fatal("Updates is open a second after Stop")
+		}
+	}
+	for range receiver {
+		// drained until closed
+	}
+
+	// Stopped before its receiver was taken
+	s, err = NewTestStream(TestOpus, EncodeProfile{}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Stop()
+	for {
+		select {
+		case receiver, ok := <-s.Updates():
+			if !ok {
+				return
+			}
+			// The receiver may still be sent as the stream stops, but is closed with nothing on it
+			if _, ok := <-receiver; ok {
+				t.Error("a packet was sent after Stop")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Updates is open a second after Stop")
+		}
+	}
+}
+
+func TestNewTestStreamChecksProfile(t *testing.T) {
+	if _, err := NewTestStream(TestH264, EncodeProfile{Width: 321, Height: 240, Framerate: 30, Bitrate: 500}, zap.NewNop()); err == nil {
+		t.Error("odd width: want an error")
+	}
+	if _, err := NewTestStream(VideoSH, EncodeProfile{}, zap.NewNop()); err == nil {
+		t.Error("not a test stream: want an error")
+	}
+}
@@ -4,15 +4,20 @@ go 1.15
 
 require (
 	github.com/bendahl/uinput v1.4.1 // indirect
+	github.com/golang-jwt/jwt/v4 v4.4.2
 	github.com/golang/protobuf v1.4.3
 	github.com/google/uuid v1.2.0
 	github.com/gorilla/mux v1.8.0
 	github.com/gorilla/websocket v1.4.2
 	github.com/linuxdeepin/go-x11-client v0.0.0-20210319100816-60170eb25590
 	github.com/pion/interceptor v0.0.12
+	github.com/pion/rtcp v1.2.6
+	github.com/pion/rtp v1.6.2
 	github.com/pion/webrtc/v3 v3.0.21
+	github.com/prometheus/client_golang v1.10.0
 	github.com/unrolled/render v1.0.3
 	github.com/urfave/negroni v1.0.0
+	go.uber.org/zap v1.17.0
 	google.golang.org/protobuf v1.25.0
 	gopkg.in/bendahl/uinput.v1 v1.1.1 // indirect
 )
@@ -0,0 +1,232 @@
+package hls
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"zoomgaming/recording"
+	"zoomgaming/remux"
+)
+
+/**
+
+HLS egress of a room, for audiences too large to each hold a WebRTC connection
+
+The egress is fed the same RTP packets as the room's tracks and forwards them to an ffmpeg process, see remux,
+which repackages the H264 video and Opus audio, without transcoding, into fMP4 segments and a playlist
+in the room's directory. The server serves the directory as it is written.
+
+Segments are cut at keyframes, so they are at least as long as the encoder's keyframe interval.
+ffmpeg does not write the partial segments of low latency HLS, so viewers trail the room by a few segments.
+
+When ffmpeg exits, it is started again after a backoff that doubles up to maxBackoff, until the egress
+is stopped. It carries on the playlist of the ffmpeg before it, so players only see a gap.
+
+*/
+
+const (
+	Playlist        = "index.m3u8"
+	initFile        = "init.mp4"
+	segmentDuration = 2 // seconds
+	playlistSize    = 6 // segments
+)
+
+var (
+	minBackoff  = 1 * time.Second
+	maxBackoff  = 30 * time.Second
+	stableAfter = 30 * time.Second // running for this long, the next failure starts over from minBackoff
+)
+
+var command = exec.CommandContext // replaced in tests, which have no ffmpeg
+
+type Egress interface {
+	WriteVideo([]byte) // an H264 rtp packet
+	WriteAudio([]byte) // an Opus rtp packet
+	Stop()             // stop ffmpeg and remove the room's segments
+}
+
+type egress struct {
+	dir    string // the room's directory
+	input  *remux.Input
+	cancel context.CancelFunc
+	done   chan struct{} // closed once the last ffmpeg exits after Stop
+	log    *zap.Logger
+}
+
+// Start repackaging a room into its directory under dir
+//
+// ffmpeg listens for the room's rtp on ports picked from the room's slot on the server, see remux
+func NewEgress(dir string, room string, roomIndex int, log *zap.Logger) (Egress, error) {
+
+	roomDir, err := recording.RoomDir(dir, room)
+	if err != nil {
+		return nil, err
+	}
+
+	// Segments of an earlier room with the same id are stale
+	if err := os.RemoveAll(roomDir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(roomDir, 0755); err != nil {
+		return nil, err
+	}
+
+	input, err := remux.NewInput(roomDir, 9004+roomIndex*4)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e := &egress{
+		dir:    roomDir,
+		input:  input,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		log:    log,
+	}
+
+	// ffmpeg that will not start at all, e.g. because it is not installed, leaves the room without HLS
+	cmd, stderr, err := e.start(ctx)
+	if err != nil {
+		cancel()
+		input.Close()
+		return nil, err
+	}
+
+	go e.run(ctx, cmd, stderr)
+
+	return e, nil
+}
+
+func (e *egress) WriteVideo(pckt []byte) {
+	e.input.WriteVideo(pckt)
+}
+
+func (e *egress) WriteAudio(pckt []byte) {
+	e.input.WriteAudio(pckt)
+}
+
+func (e *egress) Stop() {
+
+	e.cancel()
+	<-e.done
+	e.input.Close()
+
+	if err := os.RemoveAll(e.dir); err != nil {
+		e.log.Warn("Error removing HLS segments", zap.Error(err))
+	}
+}
+
+// Wait for ffmpeg until the egress is stopped, starting it again whenever it exits
+func (e *egress) run(ctx context.Context, cmd *exec.Cmd, stderr *bytes.Buffer) {
+
+	defer close(e.done)
+
+	backoff := minBackoff
+	for {
+		started := time.Now()
+		err := cmd.Wait()
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) > stableAfter {
+			backoff = minBackoff
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg[strings.LastIndex(msg, "\n")+1:])
+		}
+		if err == nil {
+			err = errors.New("ffmpeg exited")
+		}
+
+		for {
+			e.log.Warn("HLS egress exited, restarting", zap.Error(err), zap.Duration("backoff", backoff))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+
+			if cmd, stderr, err = e.start(ctx); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// Start ffmpeg, appending to the playlist of any ffmpeg before it so that players carry on
+func (e *egress) start(ctx context.Context) (*exec.Cmd, *bytes.Buffer, error) {
+
+	args := append([]string{"-loglevel", "error"}, e.input.Args()...)
+	cmd := command(ctx, "ffmpeg", append(args,
+		"-map", "0", "-c", "copy", "-strict", "experimental",
+		"-f", "hls", "-hls_time", fmt.Sprint(segmentDuration), "-hls_list_size", fmt.Sprint(playlistSize),
+		"-hls_flags", "delete_segments+independent_segments+omit_endlist+append_list",
+		"-hls_segment_type", "fmp4", "-hls_fmp4_init_filename", initFile,
+		"-hls_segment_filename", filepath.Join(e.dir, "seg-%d.m4s"),
+		filepath.Join(e.dir, Playlist))...)
+
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	return cmd, stderr, nil
+}
+
+var uriAttribute = regexp.MustCompile(`URI="([^"]*)"`)
+
+// Append a query to every uri of a playlist, so that players pass a room token on to the segments
+func WithQuery(playlist []byte, query url.Values) []byte {
+
+	if len(query) == 0 {
+		return playlist
+	}
+	q := "?" + query.Encode()
+
+	lines := bytes.Split(playlist, []byte("\n"))
+	for i, line := range lines {
+		switch {
+		case len(bytes.TrimSpace(line)) == 0:
+		case line[0] == '#':
+			lines[i] = uriAttribute.ReplaceAll(line, []byte(`URI="${1}`+q+`"`))
+		default:
+			lines[i] = append(bytes.TrimRight(line, "\r"), q...)
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// The content type of a file of the egress
+func ContentType(file string) string {
+	switch filepath.Ext(file) {
+	case ".m3u8":
+		return "application/vnd.apple.mpegurl"
+	case ".m4s":
+		return "video/iso.segment"
+	case ".mp4":
+		return "video/mp4"
+	default:
+		return "application/octet-stream"
+	}
+}
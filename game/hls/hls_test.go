@@ -0,0 +1,87 @@
+package hls
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Stands in for ffmpeg: notes that it started next to the playlist, and fails
+func TestHelperFFmpeg(t *testing.T) {
+
+	if os.Getenv("HLS_TEST_HELPER") != "1" {
+		return
+	}
+	defer os.Exit(1)
+
+	starts, err := os.OpenFile(filepath.Join(filepath.Dir(os.Args[len(os.Args)-1]), "starts"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Fprintln(starts, time.Now().UnixNano())
+	starts.Close()
+
+	fmt.Fprintln(os.Stderr, "muxer failed")
+}
+
+func helperCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=TestHelperFFmpeg", "--"}, args...)...)
+	cmd.Env = append(os.Environ(), "HLS_TEST_HELPER=1")
+	return cmd
+}
+
+func TestEgressRestarts(t *testing.T) {
+
+	command = helperCommand
+	minBackoff, maxBackoff = 100*time.Millisecond, 150*time.Millisecond
+	defer func() {
+		command = exec.CommandContext
+		minBackoff, maxBackoff = 1*time.Second, 30*time.Second
+	}()
+
+	dir, err := ioutil.TempDir("", "hls-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	e, err := NewEgress(dir, "room", 40, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ffmpeg is started again after each exit, once the backoff is over
+	var starts [][]byte
+	deadline := time.Now().Add(10 * time.Second)
+	for len(starts) < 4 {
+		if time.Now().After(deadline) {
+			t.Fatalf("ffmpeg started %d times in 10 seconds, want 4", len(starts))
+		}
+		time.Sleep(20 * time.Millisecond)
+		b, _ := ioutil.ReadFile(filepath.Join(dir, "room", "starts"))
+		starts = bytes.Fields(b)
+	}
+	var last int64
+	for i, backoff := range []time.Duration{0, 100 * time.Millisecond, 150 * time.Millisecond, 150 * time.Millisecond} {
+		var at int64
+		fmt.Sscan(string(starts[i]), &at)
+		if i > 0 && time.Duration(at-last) < backoff {
+			t.Errorf("restart %d after %s, want a backoff of at least %s", i, time.Duration(at-last), backoff)
+		}
+		last = at
+	}
+
+	e.Stop()
+	if _, err := os.Stat(filepath.Join(dir, "room")); !os.IsNotExist(err) {
+		t.Errorf("room's directory is left after Stop: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package inputlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "zoomgaming/proto"
+	"zoomgaming/recording"
+)
+
+/**
+
+Logs of the input injected into a room's games, to replay a session when something went wrong
+
+A log is a sequence of InputLogRecord messages, each prefixed with its length as a varint.
+It starts with a header naming the room and game, and each entry carries the seat that sent the input
+and when it arrived, in microseconds since the log started by the monotonic clock.
+
+*/
+
+const maxRecordSize = 1 << 16 // far more than any input event, anything longer is taken as a corrupt log
+
+type Writer interface {
+	Switch(game string)                    // write a header for the game the room switched to
+	Write(seat uint32, evt *pb.InputEvent) // log an input as arriving now
+	Close() error
+}
+
+type writer struct {
+	mu    *sync.Mutex
+	f     *os.File
+	room  string
+	start time.Time
+	err   error // the first write error, the log stops there
+}
+
+// Start a room's input log in its directory under dir
+func NewWriter(dir string, room string, game string) (w Writer, err error) {
+
+	roomDir, err := recording.RoomDir(dir, room)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(roomDir, 0755); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	f, err := os.Create(filepath.Join(roomDir, start.UTC().Format("20060102-150405.000")+".inputs"))
+	if err != nil {
+		return nil, err
+	}
+
+	ww := &writer{
+		mu:    &sync.Mutex{},
+		f:     f,
+		room:  room,
+		start: start,
+	}
+	ww.Switch(game)
+
+	if ww.err != nil {
+		f.Close()
+		return nil, ww.err
+	}
+
+	w = ww
+	return
+}
+
+func (w *writer) Switch(game string) {
+
+	now := time.Now()
+	w.write(&pb.InputLogRecord{
+		Record: &pb.InputLogRecord_Header{Header: &pb.InputLogHeader{
+			RoomId:   w.room,
+			GameId:   game,
+			At:       timestamppb.New(now),
+			OffsetUs: now.Sub(w.start).Microseconds(),
+		}},
+	})
+}
+
+func (w *writer) Write(seat uint32, evt *pb.InputEvent) {
+	w.write(&pb.InputLogRecord{
+		Record: &pb.InputLogRecord_Entry{Entry: &pb.InputLogEntry{
+			Seat:     seat,
+			OffsetUs: time.Since(w.start).Microseconds(),
+			Event:    evt,
+		}},
+	})
+}
+
+func (w *writer) write(record *pb.InputLogRecord) {
+
+	b, err := proto.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.f.Write(append(protowire.AppendVarint(nil, uint64(len(b))), b...))
+}
+
+func (w *writer) Close() error {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.f.Close(); err != nil {
+		return err
+	}
+	return w.err
+}
+
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// The next record of the log, io.EOF once there are none left
+func (r *Reader) Next() (*pb.InputLogRecord, error) {
+
+	size, err := binary.ReadUvarint(r.r) // io.EOF only if the log ends between records
+	if err != nil {
+		return nil, err
+	}
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("record of %d bytes, the log is corrupt", size)
+	}
+
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	record := &pb.InputLogRecord{}
+	if err := proto.Unmarshal(b, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
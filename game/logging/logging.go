@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+/**
+
+Structured, leveled logs
+
+Every package logs through a logger derived from L, with the fields below for whatever it is about:
+the room, the game it is playing, the peer connection, and the player's seat.
+
+The level can be changed while the server runs, with GET and PUT on the LevelHandler, which only
+the admin listener should serve PUT on, e.g.
+	curl -X PUT -d '{"level":"debug"}' 127.0.0.1:8081/log/level
+
+*/
+
+var level = zap.NewAtomicLevel()
+
+var root = newLogger(false)
+
+// The root logger, without any fields
+func L() *zap.Logger {
+	return root
+}
+
+// Pick the initial level, and whether to log JSON rather than lines for people to read
+//
+// Must be called before any other logger is derived from L
+func Configure(lvl string, json bool) error {
+
+	if err := level.UnmarshalText([]byte(lvl)); err != nil {
+		return fmt.Errorf("invalid log level %q: %s", lvl, err)
+	}
+
+	root = newLogger(json)
+	return nil
+}
+
+// Serves the current level, and changes it
+func LevelHandler() http.Handler {
+	return level
+}
+
+func newLogger(json bool) *zap.Logger {
+
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var encoder zapcore.Encoder
+	if json {
+		encoder = zapcore.NewJSONEncoder(cfg)
+	} else {
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(cfg)
+	}
+
+	return zap.New(zapcore.NewCore(encoder, zapcore.Lock(os.Stderr), level), zap.AddCaller())
+}
+
+// Fields
+
+func Room(id string) zap.Field {
+	return zap.String("room", id)
+}
+
+func Game(name string) zap.Field {
+	return zap.String("game", name)
+}
+
+func Peer(id string) zap.Field {
+	return zap.String("peer", id)
+}
+
+// 1-based seat of a player, 0 for a spectator
+func Seat(idx int) zap.Field {
+	return zap.Int("seat", idx)
+}
+
+func Client(id string) zap.Field {
+	return zap.String("client", id)
+}
@@ -1,32 +1,110 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/unrolled/render"
 	"github.com/urfave/negroni"
+	"go.uber.org/zap"
 
+	"zoomgaming/auth"
 	"zoomgaming/coordinator"
+	"zoomgaming/game"
+	"zoomgaming/hls"
+	"zoomgaming/logging"
+	pb "zoomgaming/proto"
+	"zoomgaming/recording"
+	"zoomgaming/room"
+	zrtc "zoomgaming/webrtc"
 	zws "zoomgaming/websocket"
 )
 
 var addr = flag.String("addr", ":8080", "http service address")
+var adminAddr = flag.String("admin-addr", "127.0.0.1:8081", "address of the admin listener, which may change the log level, empty for none")
+var maxSpectators = flag.Int("spectators", 16, "maximum number of spectators per room")
+var voice = flag.Bool("voice", true, "let players talk to each other, hosts can still turn it off for their room")
+var commentary = flag.Bool("commentary", false, "mix the game's audio and voice chat into a commentary track for spectators")
+var gameGain = flag.Float64("game-gain", 1, "volume of the game's audio in the commentary mix")
+var voiceGain = flag.Float64("voice-gain", 1, "volume of voice chat in the commentary mix")
+var tokenKeyFlag = flag.String("token-key", os.Getenv("ZOOMGAMING_TOKEN_KEY"), "key that room tokens are signed with")
+var recordings = flag.String("recordings", "", "directory that hosts may record their rooms into, empty to not allow recording")
+var replayLength = flag.Duration("replay", 30*time.Second, "how much of each room to keep for instant replays, 0 for none, needs -recordings")
+var inputLogs = flag.String("input-logs", "", "directory to log every room's input into, for replaying with cmd/replay, empty for no logs")
+var snapshotInterval = flag.Duration("snapshot-interval", 5*time.Second, "how long a room's snapshot is served before grabbing a new one")
+var hlsDir = flag.String("hls", "", "directory to write the HLS segments of rooms created with HLS into, for large audiences, empty for no HLS")
+var rtmpURLs = flag.String("rtmp", "", "comma separated prefixes of the rtmp urls hosts may push their rooms to, empty to not allow pushing")
+var renditionsFlag = flag.String("renditions", "", "comma separated smaller encodes of the video, e.g. 960x540@1200k,640x360@600k, for peers without the bandwidth for the full stream")
+var logLevel = flag.String("log-level", "info", "debug, info, warn or error, may be changed later on the admin listener's /log/level")
+var logJSON = flag.Bool("log-json", false, "log JSON rather than lines of text")
 var c coordinator.RoomCoordinator
+var tokenKey []byte
 
 func main() {
 
 	flag.Parse()
 	var err error
 
-	c, err = coordinator.NewRoomCoordinator(2)
+	if err := logging.Configure(*logLevel, *logJSON); err != nil {
+		log.Fatal(err)
+	}
+	defer logging.L().Sync()
+
+	if *tokenKeyFlag == "" {
+		logging.L().Fatal("a room token key is required, use -token-key or ZOOMGAMING_TOKEN_KEY")
+	}
+	tokenKey = []byte(*tokenKeyFlag)
+
+	renditions, err := parseRenditions(*renditionsFlag)
+	if err != nil {
+		logging.L().Fatal("Invalid -renditions", zap.Error(err))
+	}
+
+	roomCfg := room.Config{
+		MaxSpectators:    *maxSpectators,
+		EmptyTimeout:     2 * time.Minute,
+		Voice:            *voice,
+		RecordingDir:     *recordings,
+		ReplayLength:     *replayLength,
+		InputLogDir:      *inputLogs,
+		SnapshotInterval: *snapshotInterval,
+		HLSDir:           *hlsDir,
+		BroadcastURLs:    splitList(*rtmpURLs),
+		Renditions:       renditions,
+	}
+	if *commentary {
+		roomCfg.Commentary = &game.MixerGains{Game: *gameGain, Voice: *voiceGain}
+	}
+
+	c, err = coordinator.NewRoomCoordinator(2, roomCfg)
 	if err != nil {
-		log.Println(err)
-		os.Exit(1)
+		logging.L().Fatal("Error creating room coordinator", zap.Error(err))
+	}
+	prometheus.MustRegister(c)
+
+	if *adminAddr != "" {
+		go func() {
+			err := http.ListenAndServe(*adminAddr, NewAdminServer())
+			logging.L().Fatal("Admin listener stopped", zap.Error(err))
+		}()
 	}
 
 	server := NewServer()
@@ -51,12 +129,62 @@ func NewServer() *negroni.Negroni {
 	return n
 }
 
+// Admin http server, for operators only: keep it on a private address
+func NewAdminServer() http.Handler {
+	mx := mux.NewRouter()
+	mx.Handle("/log/level", logging.LevelHandler()).Methods("GET", "PUT")
+	return mx
+}
+
+// The non-empty elements of a comma separated flag
+func splitList(flag string) []string {
+	var list []string
+	for _, elem := range strings.Split(flag, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			list = append(list, elem)
+		}
+	}
+	return list
+}
+
+// Renditions from the largest to the smallest
+func parseRenditions(flag string) ([]game.Rendition, error) {
+
+	var renditions []game.Rendition
+	for _, elem := range splitList(flag) {
+		rend, err := game.ParseRendition(elem)
+		if err != nil {
+			return nil, err
+		}
+		renditions = append(renditions, rend)
+	}
+
+	if len(renditions) > game.MaxRenditions {
+		return nil, fmt.Errorf("at most %d renditions", game.MaxRenditions)
+	}
+
+	sort.Slice(renditions, func(i, j int) bool { return renditions[i].Bitrate > renditions[j].Bitrate })
+	return renditions, nil
+}
+
 // REST API routes
 func initRoutes(mx *mux.Router, formatter *render.Render) {
 	mx.HandleFunc("/ping", pingHandler(formatter)).Methods("GET")
+	mx.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	mx.Handle("/log/level", logging.LevelHandler()).Methods("GET") // changed on the admin listener only
 	s := mx.PathPrefix("/demo").Subrouter()
 	s.HandleFunc("", gameHandler(formatter)).Methods("GET")
 	s.HandleFunc("/{room_id}/{game_id}", gameHandler(formatter)).Methods("GET")
+	mx.HandleFunc("/rooms", createRoomHandler(formatter)).Methods("POST")
+	mx.HandleFunc("/rooms/{room_id}/stats", roomStatsHandler(formatter)).Methods("GET")
+	mx.HandleFunc("/rooms/{room_id}/peers", roomPeersHandler(formatter)).Methods("GET")
+	mx.HandleFunc("/rooms/{room_id}/recordings", recordingsHandler(formatter)).Methods("GET")
+	mx.HandleFunc("/rooms/{room_id}/clips", clipHandler(formatter)).Methods("POST")
+	mx.HandleFunc("/rooms/{room_id}/snapshot.jpg", snapshotHandler(formatter)).Methods("GET")
+	mx.HandleFunc("/rooms/{room_id}/whep", whepHandler(formatter)).Methods("POST")
+	mx.HandleFunc("/rooms/{room_id}/whep/{viewer_id}", whepResourceHandler(formatter)).Methods("DELETE")
+	mx.HandleFunc("/recordings/{room_id}/{file}", recordingFileHandler(formatter)).Methods("GET")
+	mx.HandleFunc("/hls/{room_id}/{file}", hlsHandler(formatter)).Methods("GET")
 	// mx.HandleFunc("/rooms/{room_id:[a-zA-Z0-9]+}/{gane_id:[a-zA-Z0-9]+}", roomHandler(formatter)).Methods("GET")
 }
 
@@ -66,31 +194,467 @@ func pingHandler(formatter *render.Render) http.HandlerFunc {
 	}
 }
 
+// Create a room ahead of time
+//
+// The room and game come from the caller's room token, and the caller becomes the host of the room.
+// The body may give the room an encode profile, e.g. {"width": 1920, "height": 1080, "framerate": 60, "bitrate": 6000},
+// anything it leaves out is taken from the game's profile, and may stream the room over HLS with {"hls": true}
+func createRoomHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		claims, status, err := authenticate(req)
+		if err != nil {
+			formatter.JSON(w, status, struct{ Error string }{err.Error()})
+			return
+		}
+		// Spectators may watch a room, but not open one and host it
+		if role, prs := roles[claims.Role]; !prs || role == pb.Role_ROLE_SPECTATOR {
+			formatter.JSON(w, http.StatusForbidden, struct{ Error string }{"token does not allow hosting"})
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, 64*1024))
+		if err != nil {
+			formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{err.Error()})
+			return
+		}
+
+		// The encode profile and the room's options, side by side in one object
+		var opts struct {
+			game.EncodeProfile
+			HLS bool `json:"hls"`
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := json.Unmarshal(body, &opts); err != nil {
+				formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{fmt.Sprintf("invalid room options: %s", err)})
+				return
+			}
+		}
+		if opts.HLS && *hlsDir == "" {
+			formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{"the server does not stream rooms over HLS"})
+			return
+		}
+
+		var profile *game.EncodeProfile
+		if opts.EncodeProfile != (game.EncodeProfile{}) {
+			profile = &opts.EncodeProfile
+		}
+
+		err = c.CreateRoom(claims.RoomID, claims.GameID, claims.Subject, profile, opts.HLS)
+
+		var serr *zrtc.SignalingError
+		switch {
+		case err == nil:
+			formatter.JSON(w, http.StatusCreated, struct{ RoomID, HostID string }{claims.RoomID, claims.Subject})
+		case errors.Is(err, coordinator.ErrRoomExists):
+			formatter.JSON(w, http.StatusConflict, struct{ Error string }{err.Error()})
+		case errors.As(err, &serr) && (serr.Code == pb.SignalingError_CODE_INVALID_GAME || serr.Code == pb.SignalingError_CODE_INVALID_PROFILE):
+			formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{err.Error()})
+		case errors.As(err, &serr) && serr.Code == pb.SignalingError_CODE_MAX_ROOMS:
+			formatter.JSON(w, http.StatusServiceUnavailable, struct{ Error string }{err.Error()})
+		default:
+			logging.L().Error("Error creating room", logging.Room(claims.RoomID), zap.Error(err))
+			formatter.JSON(w, http.StatusInternalServerError, struct{ Error string }{err.Error()})
+		}
+	}
+}
+
+// Players, connection stats and input latency of a room
+//
+// The caller's room token must be for the room
+func roomStatsHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if r := authorizedRoom(formatter, w, req); r != nil {
+			formatter.JSON(w, http.StatusOK, r.Stats())
+		}
+	}
+}
+
+// Connection quality of everyone in a room: bytes, packets, loss, jitter, round trips and candidate types
+//
+// The caller's room token must be for the room
+func roomPeersHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if r := authorizedRoom(formatter, w, req); r != nil {
+			formatter.JSON(w, http.StatusOK, r.Players())
+		}
+	}
+}
+
+// Recordings of a room, including those of earlier sessions of a room with the same id
+//
+// The caller's room token must be for the room, which need not be open
+func recordingsHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		claims, status, err := authenticate(req)
+		if err != nil {
+			formatter.JSON(w, status, struct{ Error string }{err.Error()})
+			return
+		}
+
+		room_id := mux.Vars(req)["room_id"]
+		if room_id != claims.RoomID {
+			formatter.JSON(w, http.StatusForbidden, struct{ Error string }{"token is for another room"})
+			return
+		}
+
+		if *recordings == "" {
+			formatter.JSON(w, http.StatusNotFound, struct{ Error string }{"the server does not record rooms"})
+			return
+		}
+
+		list, err := recording.List(*recordings, room_id)
+		if err != nil {
+			formatter.JSON(w, http.StatusInternalServerError, struct{ Error string }{err.Error()})
+			return
+		}
+
+		type listed struct {
+			recording.Recording
+			VideoURL, AudioURL string
+		}
+		res := make([]listed, 0, len(list))
+		for _, rec := range list {
+			res = append(res, listed{rec, tokenURL(req, recording.FileURL(rec.Room, rec.Video)), tokenURL(req, recording.FileURL(rec.Room, rec.Audio))})
+		}
+
+		formatter.JSON(w, http.StatusOK, res)
+	}
+}
+
+// Save the last seconds of a room as an instant replay
+//
+// The caller's room token must be for the room
+func clipHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		r := authorizedRoom(formatter, w, req)
+		if r == nil {
+			return
+		}
+
+		clip, err := r.SaveClip()
+		var serr *zrtc.SignalingError
+		switch {
+		case err == nil:
+			formatter.JSON(w, http.StatusCreated, struct {
+				Clip               recording.Recording
+				VideoURL, AudioURL string
+			}{clip, tokenURL(req, recording.FileURL(clip.Room, clip.Video)), tokenURL(req, recording.FileURL(clip.Room, clip.Audio))})
+		case errors.As(err, &serr) && serr.Code == pb.SignalingError_CODE_RATE_LIMITED:
+			formatter.JSON(w, http.StatusTooManyRequests, struct{ Error string }{err.Error()})
+		default:
+			formatter.JSON(w, http.StatusServiceUnavailable, struct{ Error string }{err.Error()})
+		}
+	}
+}
+
+// The latest frame of a room as a JPEG, for previews in the lobby
+//
+// Needs no room token, so that anyone browsing the lobby can see what is being played
+func snapshotHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		r := c.Room(mux.Vars(req)["room_id"])
+		if r == nil {
+			formatter.JSON(w, http.StatusNotFound, struct{ Error string }{"no such room"})
+			return
+		}
+
+		jpeg, at, err := r.Snapshot()
+		if err != nil {
+			formatter.JSON(w, http.StatusServiceUnavailable, struct{ Error string }{err.Error()})
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(snapshotInterval.Seconds())))
+		http.ServeContent(w, req, "snapshot.jpg", at, bytes.NewReader(jpeg))
+	}
+}
+
+// Download a file of a room's recordings or clips
+//
+// The caller's room token must be for the room, e.g. in the token query parameter of a link
+func recordingFileHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		claims, status, err := authenticate(req)
+		if err != nil {
+			formatter.JSON(w, status, struct{ Error string }{err.Error()})
+			return
+		}
+
+		vars := mux.Vars(req)
+		room_id, file := vars["room_id"], vars["file"]
+		if room_id != claims.RoomID {
+			formatter.JSON(w, http.StatusForbidden, struct{ Error string }{"token is for another room"})
+			return
+		}
+
+		dir, err := recording.RoomDir(*recordings, room_id)
+		if *recordings == "" || err != nil || file != filepath.Base(file) || strings.HasPrefix(file, ".") {
+			formatter.JSON(w, http.StatusNotFound, struct{ Error string }{"no such recording"})
+			return
+		}
+
+		http.ServeFile(w, req, filepath.Join(dir, file))
+	}
+}
+
+// The playlist and segments of a room's HLS egress
+//
+// The caller's room token must be for the room. A token passed in the token query parameter
+// is added to the uris of the playlist, since players do not pass it on by themselves
+func hlsHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		r := authorizedRoom(formatter, w, req)
+		if r == nil {
+			return
+		}
+
+		file := mux.Vars(req)["file"]
+		dir, err := recording.RoomDir(*hlsDir, mux.Vars(req)["room_id"])
+		if *hlsDir == "" || err != nil || file != filepath.Base(file) || hls.ContentType(file) == "application/octet-stream" {
+			formatter.JSON(w, http.StatusNotFound, struct{ Error string }{"no such stream"})
+			return
+		}
+		path := filepath.Join(dir, file)
+
+		w.Header().Set("Content-Type", hls.ContentType(file))
+
+		if file != hls.Playlist {
+			http.ServeFile(w, req, path)
+			return
+		}
+
+		playlist, err := ioutil.ReadFile(path)
+		if err != nil {
+			formatter.JSON(w, http.StatusNotFound, struct{ Error string }{"the stream has not started yet"})
+			return
+		}
+
+		query := url.Values{}
+		if token := req.URL.Query().Get("token"); token != "" {
+			query.Set("token", token)
+		}
+
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write(hls.WithQuery(playlist, query))
+	}
+}
+
+// Watch a room over WHEP: the body is an sdp offer, answered in the response, including every ICE candidate
+//
+// The caller's room token must be for the room, and allow spectating. The Location of the
+// response is the viewer's WHEP resource, to delete when the viewer stops watching
+func whepHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		r := authorizedRoom(formatter, w, req)
+		if r == nil {
+			return
+		}
+
+		claims, _, _ := authenticate(req) // authorizedRoom has verified the token
+		if claims.Role != "" && roles[claims.Role] != pb.Role_ROLE_SPECTATOR {
+			formatter.JSON(w, http.StatusForbidden, struct{ Error string }{"token does not allow spectating"})
+			return
+		}
+
+		if typ, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); typ != "application/sdp" {
+			formatter.JSON(w, http.StatusUnsupportedMediaType, struct{ Error string }{"expected an application/sdp offer"})
+			return
+		}
+		offer, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, 64*1024))
+		if err != nil {
+			formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{err.Error()})
+			return
+		}
+
+		viewer, err := r.WatchWHEP(claims.Subject, string(offer))
+		var serr *zrtc.SignalingError
+		switch {
+		case err == nil:
+		case errors.As(err, &serr) && (serr.Code == pb.SignalingError_CODE_BANNED || serr.Code == pb.SignalingError_CODE_ROOM_LOCKED):
+			formatter.JSON(w, http.StatusForbidden, struct{ Error string }{err.Error()})
+			return
+		case errors.As(err, &serr) && serr.Code == pb.SignalingError_CODE_SPECTATORS_FULL:
+			formatter.JSON(w, http.StatusServiceUnavailable, struct{ Error string }{err.Error()})
+			return
+		default:
+			formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{err.Error()})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/sdp")
+		w.Header().Set("Location", fmt.Sprintf("/rooms/%s/whep/%s", mux.Vars(req)["room_id"], viewer.ID()))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(viewer.Answer()))
+	}
+}
+
+// Stop a viewer watching over WHEP
+//
+// The caller's room token must be for the room, and for the client who started watching or the host
+func whepResourceHandler(formatter *render.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+
+		r := authorizedRoom(formatter, w, req)
+		if r == nil {
+			return
+		}
+
+		claims, _, _ := authenticate(req) // authorizedRoom has verified the token
+		err := r.StopWHEP(mux.Vars(req)["viewer_id"], claims.Subject)
+		var serr *zrtc.SignalingError
+		switch {
+		case err == nil:
+		case errors.As(err, &serr) && serr.Code == pb.SignalingError_CODE_NOT_HOST:
+			formatter.JSON(w, http.StatusForbidden, struct{ Error string }{err.Error()})
+			return
+		default:
+			formatter.JSON(w, http.StatusNotFound, struct{ Error string }{err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// The room of a request, if the caller's room token is for it, otherwise reply with an error and return nil
+func authorizedRoom(formatter *render.Render, w http.ResponseWriter, req *http.Request) room.Room {
+
+	claims, status, err := authenticate(req)
+	if err != nil {
+		formatter.JSON(w, status, struct{ Error string }{err.Error()})
+		return nil
+	}
+
+	room_id := mux.Vars(req)["room_id"]
+	if room_id != claims.RoomID {
+		formatter.JSON(w, http.StatusForbidden, struct{ Error string }{"token is for another room"})
+		return nil
+	}
+
+	r := c.Room(room_id)
+	if r == nil {
+		formatter.JSON(w, http.StatusNotFound, struct{ Error string }{"no such room"})
+		return nil
+	}
+
+	return r
+}
+
+// Verify the room token of a request, returning the HTTP status to reply with on failure
+func authenticate(req *http.Request) (*auth.Claims, int, error) {
+
+	token, err := auth.FromRequest(req)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	claims, err := auth.Verify(token, tokenKey)
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+
+	return claims, http.StatusOK, nil
+}
+
+// A url of the server with the room token of a request, so that the caller may download it as is
+func tokenURL(req *http.Request, path string) string {
+
+	token, err := auth.FromRequest(req)
+	if err != nil {
+		return path
+	}
+	return path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+// Values accepted by the "role" query parameter
+var roles = map[string]pb.Role{
+	"":          pb.Role_ROLE_UNSPECIFIED,
+	"player":    pb.Role_ROLE_PLAYER,
+	"spectator": pb.Role_ROLE_SPECTATOR,
+}
+
+// Query parameters:
+//
+//	token - room token, unless it is sent in the Authorization header
+//	role - "player", "spectator", or empty to take a seat if one is free
+//	seat - preferred seat when joining as a player, starting from 1
+//
+// The room and game in the path must match the room token, and default to the token's
 func gameHandler(formatter *render.Render) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 
+		claims, status, err := authenticate(req)
+		if err != nil {
+			formatter.JSON(w, status, struct{ Error string }{err.Error()})
+			return
+		}
+
 		vars := mux.Vars(req)
 
 		room_id, prs := vars["room_id"]
 		if !prs {
-			room_id = "1111"
+			room_id = claims.RoomID
 		}
 
 		game_id, prs := vars["game_id"]
 		if !prs {
-			game_id = "SpaceTime"
+			game_id = claims.GameID
+		}
+
+		if room_id != claims.RoomID || game_id != claims.GameID {
+			formatter.JSON(w, http.StatusForbidden, struct{ Error string }{"token is for another room"})
+			return
+		}
+
+		role, prs := roles[req.URL.Query().Get("role")]
+		if !prs {
+			formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{"role must be player or spectator"})
+			return
+		}
+
+		// The token's role, if any, is the only one the client may join as
+		if claims.Role != "" {
+			tokenRole, prs := roles[claims.Role]
+			if !prs || (role != pb.Role_ROLE_UNSPECIFIED && role != tokenRole) {
+				formatter.JSON(w, http.StatusForbidden, struct{ Error string }{"token does not allow this role"})
+				return
+			}
+			role = tokenRole
+		}
+
+		var seat int
+		if s := req.URL.Query().Get("seat"); s != "" {
+			var err error
+			if seat, err = strconv.Atoi(s); err != nil {
+				formatter.JSON(w, http.StatusBadRequest, struct{ Error string }{"seat must be a number"})
+				return
+			}
 		}
 
 		conn, err := upgrader.Upgrade(w, req, nil)
 		if err != nil {
-			log.Printf("upgrading http request: %s", err)
+			logging.L().Warn("Error upgrading http request", zap.Error(err))
 			return
 		}
 
 		ws := zws.NewWebSocket(conn)
 
-		if err := c.JoinRoom(room_id, game_id, ws); err != nil {
-			log.Printf("joining room: %s", err)
+		joinReq := room.JoinRequest{
+			ClientID: claims.Subject,
+			Name:     claims.Name,
+			Role:     role,
+			Seat:     game.PlayerIndex(seat),
+		}
+		if err := c.JoinRoom(room_id, game_id, ws, joinReq); err != nil {
+			logging.L().Info("Refused join", logging.Room(room_id), logging.Client(claims.Subject), zap.Error(err))
+			zrtc.SendError(ws, err)
 			ws.Close()
 		}
 	}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+/**
+
+Prometheus metrics of the server, served on /metrics
+
+Counters and histograms are registered here and updated where things happen.
+The gauges of rooms and their members are read from the coordinator when the metrics are scraped.
+
+Everything that happens in a room is labeled by the type of game it was playing at the time.
+
+*/
+
+const namespace = "zoomgaming"
+
+var (
+	Joins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "joins_total",
+		Help:      "Clients that joined a room.",
+	}, []string{"game"})
+
+	Rejects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rejects_total",
+		Help:      "Requests to join or create a room that were refused, by signaling error code.",
+	}, []string{"game", "reason"})
+
+	EncoderExits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "encoder_exits_total",
+		Help:      "Encoders of a room's video, audio or commentary that exited while the room was open.",
+	}, []string{"game", "stream"})
+
+	GameCrashes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "game_crashes_total",
+		Help:      "Games that exited while they were still being played.",
+	}, []string{"game"})
+
+	TimeToAnswer = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "signaling_time_to_answer_seconds",
+		Help:      "Time from receiving a client's offer to sending the answer, ICE gathering included.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"game"})
+
+	InputLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "input_injection_latency_seconds",
+		Help:      "Time from receiving an input to injecting it into the game.",
+		Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1},
+	}, []string{"game"})
+)
+
+// Gauges, collected from the coordinator's rooms
+var (
+	Rooms = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "rooms"),
+		"Rooms that are open.",
+		[]string{"game"}, nil)
+
+	Players = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "players"),
+		"Clients seated as players.",
+		[]string{"game"}, nil)
+
+	Spectators = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "spectators"),
+		"Clients watching as spectators, including viewers watching over WHEP.",
+		[]string{"game"}, nil)
+
+	FreeDisplays = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "free_display_slots"),
+		"Displays that no room is using, i.e. how many more rooms the server can open.",
+		nil, nil)
+)
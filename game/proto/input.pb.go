@@ -10,6 +10,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -148,7 +149,9 @@ type InputEvent struct {
 
 	// Types that are assignable to Event:
 	//	*InputEvent_KeyPressEvent
-	Event isInputEvent_Event `protobuf_oneof:"Event"`
+	Event    isInputEvent_Event     `protobuf_oneof:"Event"`
+	Sequence uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`          // counts up from 1 on each connection, so that the server can tell when input is lost; 0 if not counted
+	SentAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"` // when the client sent the event, by the client's clock
 }
 
 func (x *InputEvent) Reset() {
@@ -197,6 +200,20 @@ func (x *InputEvent) GetKeyPressEvent() *KeyPressEvent {
 	return nil
 }
 
+func (x *InputEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *InputEvent) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
 type isInputEvent_Event interface {
 	isInputEvent_Event()
 }
@@ -266,37 +283,45 @@ var File_proto_input_proto protoreflect.FileDescriptor
 
 var file_proto_input_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x22, 0x55, 0x0a, 0x0a, 0x49, 0x6e,
-	0x70, 0x75, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0f, 0x6b, 0x65, 0x79, 0x5f,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65,
-	0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x6b, 0x65, 0x79, 0x50, 0x72,
-	0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x22, 0xef, 0x02, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x73, 0x73, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x4b,
-	0x65, 0x79, 0x50, 0x72, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x44, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x2a, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18,
-	0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x73, 0x73, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x4c, 0x0a,
-	0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x49,
-	0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49,
-	0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x49, 0x52, 0x45, 0x43,
-	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x22, 0xa5, 0x01, 0x0a, 0x03,
-	0x4b, 0x65, 0x79, 0x12, 0x13, 0x0a, 0x0f, 0x4b, 0x45, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x45, 0x59, 0x5f,
-	0x41, 0x52, 0x52, 0x4f, 0x57, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x4b, 0x45,
-	0x59, 0x5f, 0x41, 0x52, 0x52, 0x4f, 0x57, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x12, 0x12,
-	0x0a, 0x0e, 0x4b, 0x45, 0x59, 0x5f, 0x41, 0x52, 0x52, 0x4f, 0x57, 0x5f, 0x4c, 0x45, 0x46, 0x54,
-	0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x4b, 0x45, 0x59, 0x5f, 0x41, 0x52, 0x52, 0x4f, 0x57, 0x5f,
-	0x52, 0x49, 0x47, 0x48, 0x54, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x59, 0x5f, 0x53,
-	0x50, 0x41, 0x43, 0x45, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x59, 0x5f, 0x4b, 0x45,
-	0x59, 0x5f, 0x41, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x59, 0x5f, 0x4b, 0x45, 0x59,
-	0x5f, 0x53, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x59, 0x5f, 0x4b, 0x45, 0x59, 0x5f,
-	0x44, 0x10, 0x08, 0x42, 0x12, 0x5a, 0x10, 0x7a, 0x6f, 0x6f, 0x6d, 0x67, 0x61, 0x6d, 0x69, 0x6e,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa6, 0x01, 0x0a, 0x0a,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0f, 0x6b, 0x65,
+	0x79, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x50,
+	0x72, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x6b, 0x65, 0x79,
+	0x50, 0x72, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0xef, 0x02, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x73,
+	0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65,
+	0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x22, 0x4c, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a,
+	0x15, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x49, 0x52, 0x45,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x49,
+	0x52, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x22, 0xa5,
+	0x01, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x13, 0x0a, 0x0f, 0x4b, 0x45, 0x59, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4b,
+	0x45, 0x59, 0x5f, 0x41, 0x52, 0x52, 0x4f, 0x57, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x12, 0x0a,
+	0x0e, 0x4b, 0x45, 0x59, 0x5f, 0x41, 0x52, 0x52, 0x4f, 0x57, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4b, 0x45, 0x59, 0x5f, 0x41, 0x52, 0x52, 0x4f, 0x57, 0x5f, 0x4c,
+	0x45, 0x46, 0x54, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x4b, 0x45, 0x59, 0x5f, 0x41, 0x52, 0x52,
+	0x4f, 0x57, 0x5f, 0x52, 0x49, 0x47, 0x48, 0x54, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45,
+	0x59, 0x5f, 0x53, 0x50, 0x41, 0x43, 0x45, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x59,
+	0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x41, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x59, 0x5f,
+	0x4b, 0x45, 0x59, 0x5f, 0x53, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x59, 0x5f, 0x4b,
+	0x45, 0x59, 0x5f, 0x44, 0x10, 0x08, 0x42, 0x12, 0x5a, 0x10, 0x7a, 0x6f, 0x6f, 0x6d, 0x67, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -314,20 +339,22 @@ func file_proto_input_proto_rawDescGZIP() []byte {
 var file_proto_input_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_proto_input_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_proto_input_proto_goTypes = []interface{}{
-	(KeyPressEvent_Direction)(0), // 0: input.KeyPressEvent.Direction
-	(KeyPressEvent_Key)(0),       // 1: input.KeyPressEvent.Key
-	(*InputEvent)(nil),           // 2: input.InputEvent
-	(*KeyPressEvent)(nil),        // 3: input.KeyPressEvent
+	(KeyPressEvent_Direction)(0),  // 0: input.KeyPressEvent.Direction
+	(KeyPressEvent_Key)(0),        // 1: input.KeyPressEvent.Key
+	(*InputEvent)(nil),            // 2: input.InputEvent
+	(*KeyPressEvent)(nil),         // 3: input.KeyPressEvent
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
 }
 var file_proto_input_proto_depIdxs = []int32{
 	3, // 0: input.InputEvent.key_press_event:type_name -> input.KeyPressEvent
-	0, // 1: input.KeyPressEvent.direction:type_name -> input.KeyPressEvent.Direction
-	1, // 2: input.KeyPressEvent.key:type_name -> input.KeyPressEvent.Key
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 1: input.InputEvent.sent_at:type_name -> google.protobuf.Timestamp
+	0, // 2: input.KeyPressEvent.direction:type_name -> input.KeyPressEvent.Direction
+	1, // 3: input.KeyPressEvent.key:type_name -> input.KeyPressEvent.Key
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_input_proto_init() }
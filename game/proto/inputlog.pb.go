@@ -0,0 +1,378 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.6.1
+// source: proto/inputlog.proto
+
+package proto
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type InputLogRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Record:
+	//	*InputLogRecord_Header
+	//	*InputLogRecord_Entry
+	Record isInputLogRecord_Record `protobuf_oneof:"Record"`
+}
+
+func (x *InputLogRecord) Reset() {
+	*x = InputLogRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_inputlog_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InputLogRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputLogRecord) ProtoMessage() {}
+
+func (x *InputLogRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inputlog_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputLogRecord.ProtoReflect.Descriptor instead.
+func (*InputLogRecord) Descriptor() ([]byte, []int) {
+	return file_proto_inputlog_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *InputLogRecord) GetRecord() isInputLogRecord_Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (x *InputLogRecord) GetHeader() *InputLogHeader {
+	if x, ok := x.GetRecord().(*InputLogRecord_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *InputLogRecord) GetEntry() *InputLogEntry {
+	if x, ok := x.GetRecord().(*InputLogRecord_Entry); ok {
+		return x.Entry
+	}
+	return nil
+}
+
+type isInputLogRecord_Record interface {
+	isInputLogRecord_Record()
+}
+
+type InputLogRecord_Header struct {
+	Header *InputLogHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type InputLogRecord_Entry struct {
+	Entry *InputLogEntry `protobuf:"bytes,2,opt,name=entry,proto3,oneof"`
+}
+
+func (*InputLogRecord_Header) isInputLogRecord_Record() {}
+
+func (*InputLogRecord_Entry) isInputLogRecord_Record() {}
+
+// Starts the log, and again whenever the host switches the room to another game
+type InputLogHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomId   string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	GameId   string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	At       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`                              // wall clock time of the header
+	OffsetUs int64                  `protobuf:"varint,4,opt,name=offset_us,json=offsetUs,proto3" json:"offset_us,omitempty"` // microseconds since the log started, by the server's monotonic clock
+}
+
+func (x *InputLogHeader) Reset() {
+	*x = InputLogHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_inputlog_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InputLogHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputLogHeader) ProtoMessage() {}
+
+func (x *InputLogHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inputlog_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputLogHeader.ProtoReflect.Descriptor instead.
+func (*InputLogHeader) Descriptor() ([]byte, []int) {
+	return file_proto_inputlog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InputLogHeader) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *InputLogHeader) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *InputLogHeader) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *InputLogHeader) GetOffsetUs() int64 {
+	if x != nil {
+		return x.OffsetUs
+	}
+	return 0
+}
+
+type InputLogEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seat     uint32      `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`                         // 1-based seat of the player that sent the input
+	OffsetUs int64       `protobuf:"varint,2,opt,name=offset_us,json=offsetUs,proto3" json:"offset_us,omitempty"` // microseconds since the log started, by the server's monotonic clock
+	Event    *InputEvent `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *InputLogEntry) Reset() {
+	*x = InputLogEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_inputlog_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InputLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputLogEntry) ProtoMessage() {}
+
+func (x *InputLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inputlog_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputLogEntry.ProtoReflect.Descriptor instead.
+func (*InputLogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_inputlog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InputLogEntry) GetSeat() uint32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *InputLogEntry) GetOffsetUs() int64 {
+	if x != nil {
+		return x.OffsetUs
+	}
+	return 0
+}
+
+func (x *InputLogEntry) GetEvent() *InputEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_proto_inputlog_proto protoreflect.FileDescriptor
+
+var file_proto_inputlog_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x6c, 0x6f, 0x67,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x6c, 0x6f, 0x67,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7f, 0x0a, 0x0e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x32, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x6c, 0x6f,
+	0x67, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x67, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x05, 0x65, 0x6e,
+	0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x6c, 0x6f, 0x67, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x08, 0x0a, 0x06, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x8b, 0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x4c,
+	0x6f, 0x67, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x02, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x02, 0x61, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x5f, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x55, 0x73, 0x22, 0x69, 0x0a, 0x0d, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x67, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x73, 0x65, 0x61, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x5f, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x55, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x12,
+	0x5a, 0x10, 0x7a, 0x6f, 0x6f, 0x6d, 0x67, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_inputlog_proto_rawDescOnce sync.Once
+	file_proto_inputlog_proto_rawDescData = file_proto_inputlog_proto_rawDesc
+)
+
+func file_proto_inputlog_proto_rawDescGZIP() []byte {
+	file_proto_inputlog_proto_rawDescOnce.Do(func() {
+		file_proto_inputlog_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_inputlog_proto_rawDescData)
+	})
+	return file_proto_inputlog_proto_rawDescData
+}
+
+var file_proto_inputlog_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_inputlog_proto_goTypes = []interface{}{
+	(*InputLogRecord)(nil),        // 0: inputlog.InputLogRecord
+	(*InputLogHeader)(nil),        // 1: inputlog.InputLogHeader
+	(*InputLogEntry)(nil),         // 2: inputlog.InputLogEntry
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*InputEvent)(nil),            // 4: input.InputEvent
+}
+var file_proto_inputlog_proto_depIdxs = []int32{
+	1, // 0: inputlog.InputLogRecord.header:type_name -> inputlog.InputLogHeader
+	2, // 1: inputlog.InputLogRecord.entry:type_name -> inputlog.InputLogEntry
+	3, // 2: inputlog.InputLogHeader.at:type_name -> google.protobuf.Timestamp
+	4, // 3: inputlog.InputLogEntry.event:type_name -> input.InputEvent
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_inputlog_proto_init() }
+func file_proto_inputlog_proto_init() {
+	if File_proto_inputlog_proto != nil {
+		return
+	}
+	file_proto_input_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_inputlog_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InputLogRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_inputlog_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InputLogHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_inputlog_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InputLogEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_inputlog_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*InputLogRecord_Header)(nil),
+		(*InputLogRecord_Entry)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_inputlog_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_inputlog_proto_goTypes,
+		DependencyIndexes: file_proto_inputlog_proto_depIdxs,
+		MessageInfos:      file_proto_inputlog_proto_msgTypes,
+	}.Build()
+	File_proto_inputlog_proto = out.File
+	file_proto_inputlog_proto_rawDesc = nil
+	file_proto_inputlog_proto_goTypes = nil
+	file_proto_inputlog_proto_depIdxs = nil
+}
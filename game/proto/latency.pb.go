@@ -0,0 +1,448 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.6.1
+// source: proto/latency.proto
+
+package proto
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// Sent in both directions across the Latency data channel
+type LatencyProbe struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Probe:
+	//	*LatencyProbe_Ping
+	//	*LatencyProbe_Pong
+	//	*LatencyProbe_Report
+	Probe isLatencyProbe_Probe `protobuf_oneof:"Probe"`
+}
+
+func (x *LatencyProbe) Reset() {
+	*x = LatencyProbe{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_latency_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyProbe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyProbe) ProtoMessage() {}
+
+func (x *LatencyProbe) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_latency_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyProbe.ProtoReflect.Descriptor instead.
+func (*LatencyProbe) Descriptor() ([]byte, []int) {
+	return file_proto_latency_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *LatencyProbe) GetProbe() isLatencyProbe_Probe {
+	if m != nil {
+		return m.Probe
+	}
+	return nil
+}
+
+func (x *LatencyProbe) GetPing() *Ping {
+	if x, ok := x.GetProbe().(*LatencyProbe_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
+func (x *LatencyProbe) GetPong() *Pong {
+	if x, ok := x.GetProbe().(*LatencyProbe_Pong); ok {
+		return x.Pong
+	}
+	return nil
+}
+
+func (x *LatencyProbe) GetReport() *LatencyReport {
+	if x, ok := x.GetProbe().(*LatencyProbe_Report); ok {
+		return x.Report
+	}
+	return nil
+}
+
+type isLatencyProbe_Probe interface {
+	isLatencyProbe_Probe()
+}
+
+type LatencyProbe_Ping struct {
+	Ping *Ping `protobuf:"bytes,1,opt,name=ping,proto3,oneof"`
+}
+
+type LatencyProbe_Pong struct {
+	Pong *Pong `protobuf:"bytes,2,opt,name=pong,proto3,oneof"`
+}
+
+type LatencyProbe_Report struct {
+	Report *LatencyReport `protobuf:"bytes,3,opt,name=report,proto3,oneof"`
+}
+
+func (*LatencyProbe_Ping) isLatencyProbe_Probe() {}
+
+func (*LatencyProbe_Pong) isLatencyProbe_Probe() {}
+
+func (*LatencyProbe_Report) isLatencyProbe_Probe() {}
+
+// Either side may ping, the other side answers right away with a pong carrying the same id
+type Ping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Ping) Reset() {
+	*x = Ping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_latency_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ping) ProtoMessage() {}
+
+func (x *Ping) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_latency_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ping.ProtoReflect.Descriptor instead.
+func (*Ping) Descriptor() ([]byte, []int) {
+	return file_proto_latency_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Ping) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Pong struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PlayoutDelayMs uint32 `protobuf:"varint,2,opt,name=playout_delay_ms,json=playoutDelayMs,proto3" json:"playout_delay_ms,omitempty"` // clients report how long video waits to be shown once it arrives, e.g. in the jitter buffer, 0 if unknown
+}
+
+func (x *Pong) Reset() {
+	*x = Pong{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_latency_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pong) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pong) ProtoMessage() {}
+
+func (x *Pong) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_latency_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pong.ProtoReflect.Descriptor instead.
+func (*Pong) Descriptor() ([]byte, []int) {
+	return file_proto_latency_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Pong) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pong) GetPlayoutDelayMs() uint32 {
+	if x != nil {
+		return x.PlayoutDelayMs
+	}
+	return 0
+}
+
+// Sent by the server every few seconds
+type LatencyReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RttMs          uint32 `protobuf:"varint,1,opt,name=rtt_ms,json=rttMs,proto3" json:"rtt_ms,omitempty"`                              // round trip across the data channel
+	IceRttMs       uint32 `protobuf:"varint,2,opt,name=ice_rtt_ms,json=iceRttMs,proto3" json:"ice_rtt_ms,omitempty"`                   // round trip measured by ICE, 0 if unknown
+	CaptureDelayMs uint32 `protobuf:"varint,3,opt,name=capture_delay_ms,json=captureDelayMs,proto3" json:"capture_delay_ms,omitempty"` // from capturing a frame of the game to sending it
+	PlayoutDelayMs uint32 `protobuf:"varint,4,opt,name=playout_delay_ms,json=playoutDelayMs,proto3" json:"playout_delay_ms,omitempty"` // as last reported by the client
+	EstimateMs     uint32 `protobuf:"varint,5,opt,name=estimate_ms,json=estimateMs,proto3" json:"estimate_ms,omitempty"`               // from capturing a frame to showing it, glass to glass
+}
+
+func (x *LatencyReport) Reset() {
+	*x = LatencyReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_latency_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyReport) ProtoMessage() {}
+
+func (x *LatencyReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_latency_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyReport.ProtoReflect.Descriptor instead.
+func (*LatencyReport) Descriptor() ([]byte, []int) {
+	return file_proto_latency_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LatencyReport) GetRttMs() uint32 {
+	if x != nil {
+		return x.RttMs
+	}
+	return 0
+}
+
+func (x *LatencyReport) GetIceRttMs() uint32 {
+	if x != nil {
+		return x.IceRttMs
+	}
+	return 0
+}
+
+func (x *LatencyReport) GetCaptureDelayMs() uint32 {
+	if x != nil {
+		return x.CaptureDelayMs
+	}
+	return 0
+}
+
+func (x *LatencyReport) GetPlayoutDelayMs() uint32 {
+	if x != nil {
+		return x.PlayoutDelayMs
+	}
+	return 0
+}
+
+func (x *LatencyReport) GetEstimateMs() uint32 {
+	if x != nil {
+		return x.EstimateMs
+	}
+	return 0
+}
+
+var File_proto_latency_proto protoreflect.FileDescriptor
+
+var file_proto_latency_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7b, 0x0a, 0x0c, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x1b, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x04, 0x70, 0x69,
+	0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x05, 0x2e, 0x50, 0x6f, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x12,
+	0x28, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x48,
+	0x00, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x50, 0x72, 0x6f,
+	0x62, 0x65, 0x22, 0x16, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x22, 0x40, 0x0a, 0x04, 0x50, 0x6f,
+	0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x64, 0x65,
+	0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x70, 0x6c,
+	0x61, 0x79, 0x6f, 0x75, 0x74, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x22, 0xb9, 0x01, 0x0a,
+	0x0d, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x72, 0x74, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x72, 0x74, 0x74, 0x4d, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x74, 0x74,
+	0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x63, 0x65, 0x52, 0x74,
+	0x74, 0x4d, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x64,
+	0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x28, 0x0a,
+	0x10, 0x70, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x73, 0x74, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x65, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x4d, 0x73, 0x42, 0x12, 0x5a, 0x10, 0x7a, 0x6f, 0x6f, 0x6d,
+	0x67, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_latency_proto_rawDescOnce sync.Once
+	file_proto_latency_proto_rawDescData = file_proto_latency_proto_rawDesc
+)
+
+func file_proto_latency_proto_rawDescGZIP() []byte {
+	file_proto_latency_proto_rawDescOnce.Do(func() {
+		file_proto_latency_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_latency_proto_rawDescData)
+	})
+	return file_proto_latency_proto_rawDescData
+}
+
+var file_proto_latency_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_latency_proto_goTypes = []interface{}{
+	(*LatencyProbe)(nil),  // 0: LatencyProbe
+	(*Ping)(nil),          // 1: Ping
+	(*Pong)(nil),          // 2: Pong
+	(*LatencyReport)(nil), // 3: LatencyReport
+}
+var file_proto_latency_proto_depIdxs = []int32{
+	1, // 0: LatencyProbe.ping:type_name -> Ping
+	2, // 1: LatencyProbe.pong:type_name -> Pong
+	3, // 2: LatencyProbe.report:type_name -> LatencyReport
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_latency_proto_init() }
+func file_proto_latency_proto_init() {
+	if File_proto_latency_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_latency_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyProbe); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_latency_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_latency_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pong); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_latency_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_latency_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*LatencyProbe_Ping)(nil),
+		(*LatencyProbe_Pong)(nil),
+		(*LatencyProbe_Report)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_latency_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_latency_proto_goTypes,
+		DependencyIndexes: file_proto_latency_proto_depIdxs,
+		MessageInfos:      file_proto_latency_proto_msgTypes,
+	}.Build()
+	File_proto_latency_proto = out.File
+	file_proto_latency_proto_rawDesc = nil
+	file_proto_latency_proto_goTypes = nil
+	file_proto_latency_proto_depIdxs = nil
+}
@@ -0,0 +1,1537 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.6.1
+// source: proto/room.proto
+
+package proto
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type Quality int32
+
+const (
+	Quality_QUALITY_UNSPECIFIED Quality = 0 // not measured yet
+	Quality_QUALITY_GOOD        Quality = 1
+	Quality_QUALITY_FAIR        Quality = 2
+	Quality_QUALITY_POOR        Quality = 3
+)
+
+// Enum value maps for Quality.
+var (
+	Quality_name = map[int32]string{
+		0: "QUALITY_UNSPECIFIED",
+		1: "QUALITY_GOOD",
+		2: "QUALITY_FAIR",
+		3: "QUALITY_POOR",
+	}
+	Quality_value = map[string]int32{
+		"QUALITY_UNSPECIFIED": 0,
+		"QUALITY_GOOD":        1,
+		"QUALITY_FAIR":        2,
+		"QUALITY_POOR":        3,
+	}
+)
+
+func (x Quality) Enum() *Quality {
+	p := new(Quality)
+	*p = x
+	return p
+}
+
+func (x Quality) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Quality) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_room_proto_enumTypes[0].Descriptor()
+}
+
+func (Quality) Type() protoreflect.EnumType {
+	return &file_proto_room_proto_enumTypes[0]
+}
+
+func (x Quality) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Quality.Descriptor instead.
+func (Quality) EnumDescriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{0}
+}
+
+// Every message sent by the server across the RoomState data channel is wrapped in a RoomEvent
+type RoomEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*RoomEvent_Members
+	//	*RoomEvent_SeatAssignment
+	//	*RoomEvent_HostChange
+	//	*RoomEvent_GameSwitch
+	//	*RoomEvent_StreamHealth
+	//	*RoomEvent_Countdown
+	//	*RoomEvent_VoiceActivity
+	//	*RoomEvent_VoiceSwitch
+	//	*RoomEvent_ConnectionQuality
+	//	*RoomEvent_Recording
+	//	*RoomEvent_ClipRequest
+	//	*RoomEvent_Clip
+	Event isRoomEvent_Event `protobuf_oneof:"Event"`
+}
+
+func (x *RoomEvent) Reset() {
+	*x = RoomEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomEvent) ProtoMessage() {}
+
+func (x *RoomEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomEvent.ProtoReflect.Descriptor instead.
+func (*RoomEvent) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *RoomEvent) GetEvent() isRoomEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetMembers() *RoomMembers {
+	if x, ok := x.GetEvent().(*RoomEvent_Members); ok {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetSeatAssignment() *SeatAssignment {
+	if x, ok := x.GetEvent().(*RoomEvent_SeatAssignment); ok {
+		return x.SeatAssignment
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetHostChange() *HostChange {
+	if x, ok := x.GetEvent().(*RoomEvent_HostChange); ok {
+		return x.HostChange
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetGameSwitch() *GameSwitch {
+	if x, ok := x.GetEvent().(*RoomEvent_GameSwitch); ok {
+		return x.GameSwitch
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetStreamHealth() *StreamHealth {
+	if x, ok := x.GetEvent().(*RoomEvent_StreamHealth); ok {
+		return x.StreamHealth
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetCountdown() *Countdown {
+	if x, ok := x.GetEvent().(*RoomEvent_Countdown); ok {
+		return x.Countdown
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetVoiceActivity() *VoiceActivity {
+	if x, ok := x.GetEvent().(*RoomEvent_VoiceActivity); ok {
+		return x.VoiceActivity
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetVoiceSwitch() *VoiceSwitch {
+	if x, ok := x.GetEvent().(*RoomEvent_VoiceSwitch); ok {
+		return x.VoiceSwitch
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetConnectionQuality() *ConnectionQuality {
+	if x, ok := x.GetEvent().(*RoomEvent_ConnectionQuality); ok {
+		return x.ConnectionQuality
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetRecording() *Recording {
+	if x, ok := x.GetEvent().(*RoomEvent_Recording); ok {
+		return x.Recording
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetClipRequest() *ClipRequest {
+	if x, ok := x.GetEvent().(*RoomEvent_ClipRequest); ok {
+		return x.ClipRequest
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetClip() *Clip {
+	if x, ok := x.GetEvent().(*RoomEvent_Clip); ok {
+		return x.Clip
+	}
+	return nil
+}
+
+type isRoomEvent_Event interface {
+	isRoomEvent_Event()
+}
+
+type RoomEvent_Members struct {
+	Members *RoomMembers `protobuf:"bytes,1,opt,name=members,proto3,oneof"`
+}
+
+type RoomEvent_SeatAssignment struct {
+	SeatAssignment *SeatAssignment `protobuf:"bytes,2,opt,name=seat_assignment,json=seatAssignment,proto3,oneof"`
+}
+
+type RoomEvent_HostChange struct {
+	HostChange *HostChange `protobuf:"bytes,3,opt,name=host_change,json=hostChange,proto3,oneof"`
+}
+
+type RoomEvent_GameSwitch struct {
+	GameSwitch *GameSwitch `protobuf:"bytes,4,opt,name=game_switch,json=gameSwitch,proto3,oneof"`
+}
+
+type RoomEvent_StreamHealth struct {
+	StreamHealth *StreamHealth `protobuf:"bytes,5,opt,name=stream_health,json=streamHealth,proto3,oneof"`
+}
+
+type RoomEvent_Countdown struct {
+	Countdown *Countdown `protobuf:"bytes,6,opt,name=countdown,proto3,oneof"`
+}
+
+type RoomEvent_VoiceActivity struct {
+	VoiceActivity *VoiceActivity `protobuf:"bytes,7,opt,name=voice_activity,json=voiceActivity,proto3,oneof"`
+}
+
+type RoomEvent_VoiceSwitch struct {
+	VoiceSwitch *VoiceSwitch `protobuf:"bytes,8,opt,name=voice_switch,json=voiceSwitch,proto3,oneof"`
+}
+
+type RoomEvent_ConnectionQuality struct {
+	ConnectionQuality *ConnectionQuality `protobuf:"bytes,9,opt,name=connection_quality,json=connectionQuality,proto3,oneof"`
+}
+
+type RoomEvent_Recording struct {
+	Recording *Recording `protobuf:"bytes,10,opt,name=recording,proto3,oneof"`
+}
+
+type RoomEvent_ClipRequest struct {
+	ClipRequest *ClipRequest `protobuf:"bytes,11,opt,name=clip_request,json=clipRequest,proto3,oneof"`
+}
+
+type RoomEvent_Clip struct {
+	Clip *Clip `protobuf:"bytes,12,opt,name=clip,proto3,oneof"`
+}
+
+func (*RoomEvent_Members) isRoomEvent_Event() {}
+
+func (*RoomEvent_SeatAssignment) isRoomEvent_Event() {}
+
+func (*RoomEvent_HostChange) isRoomEvent_Event() {}
+
+func (*RoomEvent_GameSwitch) isRoomEvent_Event() {}
+
+func (*RoomEvent_StreamHealth) isRoomEvent_Event() {}
+
+func (*RoomEvent_Countdown) isRoomEvent_Event() {}
+
+func (*RoomEvent_VoiceActivity) isRoomEvent_Event() {}
+
+func (*RoomEvent_VoiceSwitch) isRoomEvent_Event() {}
+
+func (*RoomEvent_ConnectionQuality) isRoomEvent_Event() {}
+
+func (*RoomEvent_Recording) isRoomEvent_Event() {}
+
+func (*RoomEvent_ClipRequest) isRoomEvent_Event() {}
+
+func (*RoomEvent_Clip) isRoomEvent_Event() {}
+
+// A client in a room, seated as a player or watching as a spectator
+type Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role     Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=Role" json:"role,omitempty"`
+	Seat     uint32                 `protobuf:"varint,4,opt,name=seat,proto3" json:"seat,omitempty"` // 1-based seat of a player, 0 for a spectator
+	JoinedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Player) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Player) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Player) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Player) GetSeat() uint32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *Player) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+// Sent to everyone in the room when someone joins, leaves, or changes seats
+type RoomMembers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Players []*Player `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"` // ordered by seat, then by join time
+	HostId  string    `protobuf:"bytes,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+}
+
+func (x *RoomMembers) Reset() {
+	*x = RoomMembers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomMembers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomMembers) ProtoMessage() {}
+
+func (x *RoomMembers) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomMembers.ProtoReflect.Descriptor instead.
+func (*RoomMembers) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RoomMembers) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *RoomMembers) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+// Sent to a connection when its role or seat changes
+type SeatAssignment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Role Role   `protobuf:"varint,1,opt,name=role,proto3,enum=Role" json:"role,omitempty"`
+	Seat uint32 `protobuf:"varint,2,opt,name=seat,proto3" json:"seat,omitempty"` // 1-based seat of a player, 0 for a spectator
+}
+
+func (x *SeatAssignment) Reset() {
+	*x = SeatAssignment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeatAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatAssignment) ProtoMessage() {}
+
+func (x *SeatAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatAssignment.ProtoReflect.Descriptor instead.
+func (*SeatAssignment) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SeatAssignment) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *SeatAssignment) GetSeat() uint32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+// Sent to everyone in the room when the host changes
+type HostChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HostId string `protobuf:"bytes,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+}
+
+func (x *HostChange) Reset() {
+	*x = HostChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostChange) ProtoMessage() {}
+
+func (x *HostChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostChange.ProtoReflect.Descriptor instead.
+func (*HostChange) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HostChange) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+// Sent to everyone in the room when the host switches to another game
+type GameSwitch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+}
+
+func (x *GameSwitch) Reset() {
+	*x = GameSwitch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameSwitch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameSwitch) ProtoMessage() {}
+
+func (x *GameSwitch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameSwitch.ProtoReflect.Descriptor instead.
+func (*GameSwitch) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GameSwitch) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+// Sent periodically, so that clients can tell a stalled stream from a quiet game
+type StreamHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VideoLive bool   `protobuf:"varint,1,opt,name=video_live,json=videoLive,proto3" json:"video_live,omitempty"` // video packets arrived since the last report
+	AudioLive bool   `protobuf:"varint,2,opt,name=audio_live,json=audioLive,proto3" json:"audio_live,omitempty"`
+	VideoKbps uint32 `protobuf:"varint,3,opt,name=video_kbps,json=videoKbps,proto3" json:"video_kbps,omitempty"`
+	AudioKbps uint32 `protobuf:"varint,4,opt,name=audio_kbps,json=audioKbps,proto3" json:"audio_kbps,omitempty"`
+}
+
+func (x *StreamHealth) Reset() {
+	*x = StreamHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamHealth) ProtoMessage() {}
+
+func (x *StreamHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamHealth.ProtoReflect.Descriptor instead.
+func (*StreamHealth) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamHealth) GetVideoLive() bool {
+	if x != nil {
+		return x.VideoLive
+	}
+	return false
+}
+
+func (x *StreamHealth) GetAudioLive() bool {
+	if x != nil {
+		return x.AudioLive
+	}
+	return false
+}
+
+func (x *StreamHealth) GetVideoKbps() uint32 {
+	if x != nil {
+		return x.VideoKbps
+	}
+	return 0
+}
+
+func (x *StreamHealth) GetAudioKbps() uint32 {
+	if x != nil {
+		return x.AudioKbps
+	}
+	return 0
+}
+
+// Sent once a second while the host counts down, e.g. to the start of a round
+type Countdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Remaining uint32 `protobuf:"varint,1,opt,name=remaining,proto3" json:"remaining,omitempty"` // seconds left, 0 once the countdown is over
+	Cancelled bool   `protobuf:"varint,2,opt,name=cancelled,proto3" json:"cancelled,omitempty"` // the host stopped the countdown early
+}
+
+func (x *Countdown) Reset() {
+	*x = Countdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Countdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Countdown) ProtoMessage() {}
+
+func (x *Countdown) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Countdown.ProtoReflect.Descriptor instead.
+func (*Countdown) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Countdown) GetRemaining() uint32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *Countdown) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+// Sent to everyone in the room when a player starts or stops talking
+type VoiceActivity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Talking  bool   `protobuf:"varint,2,opt,name=talking,proto3" json:"talking,omitempty"`
+}
+
+func (x *VoiceActivity) Reset() {
+	*x = VoiceActivity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoiceActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceActivity) ProtoMessage() {}
+
+func (x *VoiceActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceActivity.ProtoReflect.Descriptor instead.
+func (*VoiceActivity) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VoiceActivity) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *VoiceActivity) GetTalking() bool {
+	if x != nil {
+		return x.Talking
+	}
+	return false
+}
+
+// Sent to everyone in the room when the host turns voice chat on or off
+type VoiceSwitch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *VoiceSwitch) Reset() {
+	*x = VoiceSwitch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoiceSwitch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceSwitch) ProtoMessage() {}
+
+func (x *VoiceSwitch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceSwitch.ProtoReflect.Descriptor instead.
+func (*VoiceSwitch) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VoiceSwitch) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// The connection quality of one client, graded from its packet loss and round trip time
+type PeerQuality struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId      string  `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Quality       Quality `protobuf:"varint,2,opt,name=quality,proto3,enum=Quality" json:"quality,omitempty"`
+	RttMs         uint32  `protobuf:"varint,3,opt,name=rtt_ms,json=rttMs,proto3" json:"rtt_ms,omitempty"`
+	Loss          float32 `protobuf:"fixed32,4,opt,name=loss,proto3" json:"loss,omitempty"` // fraction of packets lost, between 0 and 1
+	JitterMs      uint32  `protobuf:"varint,5,opt,name=jitter_ms,json=jitterMs,proto3" json:"jitter_ms,omitempty"`
+	CandidateType string  `protobuf:"bytes,6,opt,name=candidate_type,json=candidateType,proto3" json:"candidate_type,omitempty"` // host, srflx, prflx or relay
+}
+
+func (x *PeerQuality) Reset() {
+	*x = PeerQuality{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerQuality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerQuality) ProtoMessage() {}
+
+func (x *PeerQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerQuality.ProtoReflect.Descriptor instead.
+func (*PeerQuality) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PeerQuality) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *PeerQuality) GetQuality() Quality {
+	if x != nil {
+		return x.Quality
+	}
+	return Quality_QUALITY_UNSPECIFIED
+}
+
+func (x *PeerQuality) GetRttMs() uint32 {
+	if x != nil {
+		return x.RttMs
+	}
+	return 0
+}
+
+func (x *PeerQuality) GetLoss() float32 {
+	if x != nil {
+		return x.Loss
+	}
+	return 0
+}
+
+func (x *PeerQuality) GetJitterMs() uint32 {
+	if x != nil {
+		return x.JitterMs
+	}
+	return 0
+}
+
+func (x *PeerQuality) GetCandidateType() string {
+	if x != nil {
+		return x.CandidateType
+	}
+	return ""
+}
+
+// Sent to everyone in the room every few seconds
+type ConnectionQuality struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peers []*PeerQuality `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (x *ConnectionQuality) Reset() {
+	*x = ConnectionQuality{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionQuality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionQuality) ProtoMessage() {}
+
+func (x *ConnectionQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionQuality.ProtoReflect.Descriptor instead.
+func (*ConnectionQuality) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConnectionQuality) GetPeers() []*PeerQuality {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// Sent to everyone in the room when recording starts or stops, and when a connection opens its RoomState channel while the room is recording
+type Recording struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Active    bool                   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+}
+
+func (x *Recording) Reset() {
+	*x = Recording{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recording) ProtoMessage() {}
+
+func (x *Recording) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recording.ProtoReflect.Descriptor instead.
+func (*Recording) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Recording) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Recording) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+// Sent by any client on its RoomState channel to save the last seconds of the room as an instant replay
+type ClipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClipRequest) Reset() {
+	*x = ClipRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClipRequest) ProtoMessage() {}
+
+func (x *ClipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClipRequest.ProtoReflect.Descriptor instead.
+func (*ClipRequest) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{13}
+}
+
+// Sent to the client that asked for a clip once it is saved
+type Clip struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VideoUrl string `protobuf:"bytes,2,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"` // H264 Annex-B, without a token: append the client's room token as the "token" query parameter
+	AudioUrl string `protobuf:"bytes,3,opt,name=audio_url,json=audioUrl,proto3" json:"audio_url,omitempty"` // Ogg Opus
+	LengthMs uint32 `protobuf:"varint,4,opt,name=length_ms,json=lengthMs,proto3" json:"length_ms,omitempty"`
+}
+
+func (x *Clip) Reset() {
+	*x = Clip{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_room_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Clip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Clip) ProtoMessage() {}
+
+func (x *Clip) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Clip.ProtoReflect.Descriptor instead.
+func (*Clip) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Clip) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Clip) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *Clip) GetAudioUrl() string {
+	if x != nil {
+		return x.AudioUrl
+	}
+	return ""
+}
+
+func (x *Clip) GetLengthMs() uint32 {
+	if x != nil {
+		return x.LengthMs
+	}
+	return 0
+}
+
+var File_proto_room_proto protoreflect.FileDescriptor
+
+var file_proto_room_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x6f, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9, 0x04, 0x0a, 0x09, 0x52,
+	0x6f, 0x6f, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x12, 0x3a, 0x0a, 0x0f, 0x73, 0x65, 0x61, 0x74, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x53, 0x65,
+	0x61, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0e,
+	0x73, 0x65, 0x61, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2e,
+	0x0a, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2e,
+	0x0a, 0x0b, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68,
+	0x48, 0x00, 0x52, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x34,
+	0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x12, 0x2a, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x64,
+	0x6f, 0x77, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x64, 0x6f, 0x77, 0x6e,
+	0x12, 0x37, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69,
+	0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x31, 0x0a, 0x0c, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x5f, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52,
+	0x0b, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x43, 0x0a, 0x12,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x11,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x2a, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a,
+	0x0c, 0x63, 0x6c, 0x69, 0x70, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x43, 0x6c, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x04, 0x63, 0x6c, 0x69, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05,
+	0x2e, 0x43, 0x6c, 0x69, 0x70, 0x48, 0x00, 0x52, 0x04, 0x63, 0x6c, 0x69, 0x70, 0x42, 0x07, 0x0a,
+	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0xa1, 0x01, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x05, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x65, 0x61,
+	0x74, 0x12, 0x37, 0x0a, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x22, 0x49, 0x0a, 0x0b, 0x52, 0x6f,
+	0x6f, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x07, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x17, 0x0a, 0x07,
+	0x68, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68,
+	0x6f, 0x73, 0x74, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x74, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x05, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x73, 0x65, 0x61, 0x74, 0x22, 0x25, 0x0a, 0x0a, 0x48, 0x6f, 0x73, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x22, 0x25, 0x0a,
+	0x0a, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x67,
+	0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61,
+	0x6d, 0x65, 0x49, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x6c,
+	0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x76, 0x69, 0x64, 0x65, 0x6f,
+	0x4c, 0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x6c, 0x69,
+	0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4c,
+	0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x6b, 0x62, 0x70,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x4b, 0x62,
+	0x70, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x6b, 0x62, 0x70, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4b, 0x62, 0x70,
+	0x73, 0x22, 0x47, 0x0a, 0x09, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x22, 0x46, 0x0a, 0x0d, 0x56, 0x6f,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x6c, 0x6b,
+	0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74, 0x61, 0x6c, 0x6b, 0x69,
+	0x6e, 0x67, 0x22, 0x27, 0x0a, 0x0b, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63,
+	0x68, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x0b,
+	0x50, 0x65, 0x65, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x07, 0x71, 0x75, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x08, 0x2e, 0x51, 0x75, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x52, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x15, 0x0a, 0x06,
+	0x72, 0x74, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x74,
+	0x74, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x04, 0x6c, 0x6f, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6a, 0x69, 0x74, 0x74,
+	0x65, 0x72, 0x4d, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x37, 0x0a, 0x11, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x22, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x05, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x22, 0x5e, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x0d, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x6d, 0x0a, 0x04, 0x43, 0x6c, 0x69, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x76,
+	0x69, 0x64, 0x65, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x76, 0x69, 0x64, 0x65, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69,
+	0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x64,
+	0x69, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f,
+	0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x4d, 0x73, 0x2a, 0x58, 0x0a, 0x07, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x17, 0x0a,
+	0x13, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x54,
+	0x59, 0x5f, 0x47, 0x4f, 0x4f, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x51, 0x55, 0x41, 0x4c,
+	0x49, 0x54, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x52, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x51, 0x55,
+	0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x4f, 0x52, 0x10, 0x03, 0x42, 0x12, 0x5a, 0x10,
+	0x7a, 0x6f, 0x6f, 0x6d, 0x67, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_room_proto_rawDescOnce sync.Once
+	file_proto_room_proto_rawDescData = file_proto_room_proto_rawDesc
+)
+
+func file_proto_room_proto_rawDescGZIP() []byte {
+	file_proto_room_proto_rawDescOnce.Do(func() {
+		file_proto_room_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_room_proto_rawDescData)
+	})
+	return file_proto_room_proto_rawDescData
+}
+
+var file_proto_room_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_room_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_room_proto_goTypes = []interface{}{
+	(Quality)(0),                  // 0: Quality
+	(*RoomEvent)(nil),             // 1: RoomEvent
+	(*Player)(nil),                // 2: Player
+	(*RoomMembers)(nil),           // 3: RoomMembers
+	(*SeatAssignment)(nil),        // 4: SeatAssignment
+	(*HostChange)(nil),            // 5: HostChange
+	(*GameSwitch)(nil),            // 6: GameSwitch
+	(*StreamHealth)(nil),          // 7: StreamHealth
+	(*Countdown)(nil),             // 8: Countdown
+	(*VoiceActivity)(nil),         // 9: VoiceActivity
+	(*VoiceSwitch)(nil),           // 10: VoiceSwitch
+	(*PeerQuality)(nil),           // 11: PeerQuality
+	(*ConnectionQuality)(nil),     // 12: ConnectionQuality
+	(*Recording)(nil),             // 13: Recording
+	(*ClipRequest)(nil),           // 14: ClipRequest
+	(*Clip)(nil),                  // 15: Clip
+	(Role)(0),                     // 16: Role
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+}
+var file_proto_room_proto_depIdxs = []int32{
+	3,  // 0: RoomEvent.members:type_name -> RoomMembers
+	4,  // 1: RoomEvent.seat_assignment:type_name -> SeatAssignment
+	5,  // 2: RoomEvent.host_change:type_name -> HostChange
+	6,  // 3: RoomEvent.game_switch:type_name -> GameSwitch
+	7,  // 4: RoomEvent.stream_health:type_name -> StreamHealth
+	8,  // 5: RoomEvent.countdown:type_name -> Countdown
+	9,  // 6: RoomEvent.voice_activity:type_name -> VoiceActivity
+	10, // 7: RoomEvent.voice_switch:type_name -> VoiceSwitch
+	12, // 8: RoomEvent.connection_quality:type_name -> ConnectionQuality
+	13, // 9: RoomEvent.recording:type_name -> Recording
+	14, // 10: RoomEvent.clip_request:type_name -> ClipRequest
+	15, // 11: RoomEvent.clip:type_name -> Clip
+	16, // 12: Player.role:type_name -> Role
+	17, // 13: Player.joined_at:type_name -> google.protobuf.Timestamp
+	2,  // 14: RoomMembers.players:type_name -> Player
+	16, // 15: SeatAssignment.role:type_name -> Role
+	0,  // 16: PeerQuality.quality:type_name -> Quality
+	11, // 17: ConnectionQuality.peers:type_name -> PeerQuality
+	17, // 18: Recording.started_at:type_name -> google.protobuf.Timestamp
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_proto_room_proto_init() }
+func file_proto_room_proto_init() {
+	if File_proto_room_proto != nil {
+		return
+	}
+	file_proto_signaling_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_room_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Player); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomMembers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatAssignment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameSwitch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Countdown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoiceActivity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoiceSwitch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerQuality); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionQuality); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recording); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClipRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_room_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Clip); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_room_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*RoomEvent_Members)(nil),
+		(*RoomEvent_SeatAssignment)(nil),
+		(*RoomEvent_HostChange)(nil),
+		(*RoomEvent_GameSwitch)(nil),
+		(*RoomEvent_StreamHealth)(nil),
+		(*RoomEvent_Countdown)(nil),
+		(*RoomEvent_VoiceActivity)(nil),
+		(*RoomEvent_VoiceSwitch)(nil),
+		(*RoomEvent_ConnectionQuality)(nil),
+		(*RoomEvent_Recording)(nil),
+		(*RoomEvent_ClipRequest)(nil),
+		(*RoomEvent_Clip)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_room_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_room_proto_goTypes,
+		DependencyIndexes: file_proto_room_proto_depIdxs,
+		EnumInfos:         file_proto_room_proto_enumTypes,
+		MessageInfos:      file_proto_room_proto_msgTypes,
+	}.Build()
+	File_proto_room_proto = out.File
+	file_proto_room_proto_rawDesc = nil
+	file_proto_room_proto_goTypes = nil
+	file_proto_room_proto_depIdxs = nil
+}
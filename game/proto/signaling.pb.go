@@ -25,6 +25,56 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// What a connection does in a room
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0 // when joining, take a seat if there is one and spectate otherwise
+	Role_ROLE_PLAYER      Role = 1
+	Role_ROLE_SPECTATOR   Role = 2
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_PLAYER",
+		2: "ROLE_SPECTATOR",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_PLAYER":      1,
+		"ROLE_SPECTATOR":   2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_signaling_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_proto_signaling_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{0}
+}
+
 // https://pkg.go.dev/github.com/pion/webrtc/v3#SDPType
 type SessionDescription_SDPType int32
 
@@ -77,11 +127,11 @@ func (x SessionDescription_SDPType) String() string {
 }
 
 func (SessionDescription_SDPType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_signaling_proto_enumTypes[0].Descriptor()
+	return file_proto_signaling_proto_enumTypes[1].Descriptor()
 }
 
 func (SessionDescription_SDPType) Type() protoreflect.EnumType {
-	return &file_proto_signaling_proto_enumTypes[0]
+	return &file_proto_signaling_proto_enumTypes[1]
 }
 
 func (x SessionDescription_SDPType) Number() protoreflect.EnumNumber {
@@ -93,6 +143,109 @@ func (SessionDescription_SDPType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_signaling_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type SignalingError_Code int32
+
+const (
+	SignalingError_CODE_UNSPECIFIED           SignalingError_Code = 0
+	SignalingError_CODE_INVALID_GAME          SignalingError_Code = 1  // the game id in the URL is not a known game
+	SignalingError_CODE_MAX_ROOMS             SignalingError_Code = 2  // the server is already running as many rooms as it can
+	SignalingError_CODE_ROOM_FULL             SignalingError_Code = 3  // every seat and every spectator slot in the room is taken
+	SignalingError_CODE_SEATS_FULL            SignalingError_Code = 4  // asked to play but every seat is taken
+	SignalingError_CODE_SPECTATORS_FULL       SignalingError_Code = 5  // asked to spectate but every spectator slot is taken
+	SignalingError_CODE_INVALID_SEAT          SignalingError_Code = 6  // asked for a seat that the game does not have
+	SignalingError_CODE_INPUT_REJECTED        SignalingError_Code = 7  // sent game input without a seat, the connection stays open
+	SignalingError_CODE_NOT_FOUND             SignalingError_Code = 8  // the connection or seat named in a room operation is not in the room
+	SignalingError_CODE_SEAT_TAKEN            SignalingError_Code = 9  // the seat named in a room operation already has a player
+	SignalingError_CODE_NOT_HOST              SignalingError_Code = 10 // sent a room control without being the host
+	SignalingError_CODE_ROOM_LOCKED           SignalingError_Code = 11 // the host is not letting anyone else join
+	SignalingError_CODE_BANNED                SignalingError_Code = 12 // the host banned this client from the room
+	SignalingError_CODE_ALREADY_JOINED        SignalingError_Code = 13 // the client is already in the room on another connection
+	SignalingError_CODE_KICKED                SignalingError_Code = 14 // the host removed this connection from the room
+	SignalingError_CODE_INVALID_TARGET        SignalingError_Code = 15 // the host cannot kick or ban themselves
+	SignalingError_CODE_MESSAGE_TOO_LONG      SignalingError_Code = 16 // a chat message is longer than the room allows, it was not sent
+	SignalingError_CODE_RATE_LIMITED          SignalingError_Code = 17 // sent chat messages faster than the room allows, the message was not sent
+	SignalingError_CODE_RECORDING_UNAVAILABLE SignalingError_Code = 18 // the server does not record rooms, or could not start recording
+	SignalingError_CODE_BROADCAST_UNAVAILABLE SignalingError_Code = 19 // the server does not push rooms to the rtmp url, or could not start pushing
+	SignalingError_CODE_INVALID_PROFILE       SignalingError_Code = 20 // the room's encode profile is out of bounds, or larger than its display
+)
+
+// Enum value maps for SignalingError_Code.
+var (
+	SignalingError_Code_name = map[int32]string{
+		0:  "CODE_UNSPECIFIED",
+		1:  "CODE_INVALID_GAME",
+		2:  "CODE_MAX_ROOMS",
+		3:  "CODE_ROOM_FULL",
+		4:  "CODE_SEATS_FULL",
+		5:  "CODE_SPECTATORS_FULL",
+		6:  "CODE_INVALID_SEAT",
+		7:  "CODE_INPUT_REJECTED",
+		8:  "CODE_NOT_FOUND",
+		9:  "CODE_SEAT_TAKEN",
+		10: "CODE_NOT_HOST",
+		11: "CODE_ROOM_LOCKED",
+		12: "CODE_BANNED",
+		13: "CODE_ALREADY_JOINED",
+		14: "CODE_KICKED",
+		15: "CODE_INVALID_TARGET",
+		16: "CODE_MESSAGE_TOO_LONG",
+		17: "CODE_RATE_LIMITED",
+		18: "CODE_RECORDING_UNAVAILABLE",
+		19: "CODE_BROADCAST_UNAVAILABLE",
+		20: "CODE_INVALID_PROFILE",
+	}
+	SignalingError_Code_value = map[string]int32{
+		"CODE_UNSPECIFIED":           0,
+		"CODE_INVALID_GAME":          1,
+		"CODE_MAX_ROOMS":             2,
+		"CODE_ROOM_FULL":             3,
+		"CODE_SEATS_FULL":            4,
+		"CODE_SPECTATORS_FULL":       5,
+		"CODE_INVALID_SEAT":          6,
+		"CODE_INPUT_REJECTED":        7,
+		"CODE_NOT_FOUND":             8,
+		"CODE_SEAT_TAKEN":            9,
+		"CODE_NOT_HOST":              10,
+		"CODE_ROOM_LOCKED":           11,
+		"CODE_BANNED":                12,
+		"CODE_ALREADY_JOINED":        13,
+		"CODE_KICKED":                14,
+		"CODE_INVALID_TARGET":        15,
+		"CODE_MESSAGE_TOO_LONG":      16,
+		"CODE_RATE_LIMITED":          17,
+		"CODE_RECORDING_UNAVAILABLE": 18,
+		"CODE_BROADCAST_UNAVAILABLE": 19,
+		"CODE_INVALID_PROFILE":       20,
+	}
+)
+
+func (x SignalingError_Code) Enum() *SignalingError_Code {
+	p := new(SignalingError_Code)
+	*p = x
+	return p
+}
+
+func (x SignalingError_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SignalingError_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_signaling_proto_enumTypes[2].Descriptor()
+}
+
+func (SignalingError_Code) Type() protoreflect.EnumType {
+	return &file_proto_signaling_proto_enumTypes[2]
+}
+
+func (x SignalingError_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SignalingError_Code.Descriptor instead.
+func (SignalingError_Code) EnumDescriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{2, 0}
+}
+
 // https://developer.mozilla.org/en-US/docs/Web/API/RTCSessionDescription
 type SessionDescription struct {
 	state         protoimpl.MessageState
@@ -150,85 +303,1119 @@ func (x *SessionDescription) GetSdp() string {
 	return ""
 }
 
-var File_proto_signaling_proto protoreflect.FileDescriptor
+// Every message sent across the signaling WebSocket, in either direction, is wrapped in a SignalingEvent
+type SignalingEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_proto_signaling_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e,
-	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8b, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x53, 0x44, 0x50, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x73, 0x64, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x64,
-	0x70, 0x22, 0xb1, 0x01, 0x0a, 0x07, 0x53, 0x44, 0x50, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
-	0x14, 0x53, 0x44, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x6f, 0x66, 0x66, 0x65, 0x72,
-	0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x44, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4f,
-	0x46, 0x46, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x70, 0x72, 0x61, 0x6e, 0x73, 0x77,
-	0x65, 0x72, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x44, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x50, 0x52, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x61,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x44, 0x50, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08,
-	0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x44,
-	0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x10,
-	0x04, 0x1a, 0x02, 0x10, 0x01, 0x42, 0x12, 0x5a, 0x10, 0x7a, 0x6f, 0x6f, 0x6d, 0x67, 0x61, 0x6d,
-	0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	// Types that are assignable to Event:
+	//	*SignalingEvent_SessionDescription
+	//	*SignalingEvent_Error
+	//	*SignalingEvent_JoinResponse
+	//	*SignalingEvent_RoomControl
+	//	*SignalingEvent_VoiceControl
+	Event isSignalingEvent_Event `protobuf_oneof:"Event"`
 }
 
-var (
-	file_proto_signaling_proto_rawDescOnce sync.Once
-	file_proto_signaling_proto_rawDescData = file_proto_signaling_proto_rawDesc
-)
+func (x *SignalingEvent) Reset() {
+	*x = SignalingEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_proto_signaling_proto_rawDescGZIP() []byte {
-	file_proto_signaling_proto_rawDescOnce.Do(func() {
-		file_proto_signaling_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_signaling_proto_rawDescData)
-	})
-	return file_proto_signaling_proto_rawDescData
+func (x *SignalingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_proto_signaling_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_signaling_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_proto_signaling_proto_goTypes = []interface{}{
-	(SessionDescription_SDPType)(0), // 0: SessionDescription.SDPType
-	(*SessionDescription)(nil),      // 1: SessionDescription
+func (*SignalingEvent) ProtoMessage() {}
+
+func (x *SignalingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_proto_signaling_proto_depIdxs = []int32{
-	0, // 0: SessionDescription.type:type_name -> SessionDescription.SDPType
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+// Deprecated: Use SignalingEvent.ProtoReflect.Descriptor instead.
+func (*SignalingEvent) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{1}
 }
 
-func init() { file_proto_signaling_proto_init() }
-func file_proto_signaling_proto_init() {
-	if File_proto_signaling_proto != nil {
-		return
+func (m *SignalingEvent) GetEvent() isSignalingEvent_Event {
+	if m != nil {
+		return m.Event
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_signaling_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SessionDescription); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+func (x *SignalingEvent) GetSessionDescription() *SessionDescription {
+	if x, ok := x.GetEvent().(*SignalingEvent_SessionDescription); ok {
+		return x.SessionDescription
+	}
+	return nil
+}
+
+func (x *SignalingEvent) GetError() *SignalingError {
+	if x, ok := x.GetEvent().(*SignalingEvent_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *SignalingEvent) GetJoinResponse() *JoinResponse {
+	if x, ok := x.GetEvent().(*SignalingEvent_JoinResponse); ok {
+		return x.JoinResponse
+	}
+	return nil
+}
+
+func (x *SignalingEvent) GetRoomControl() *RoomControl {
+	if x, ok := x.GetEvent().(*SignalingEvent_RoomControl); ok {
+		return x.RoomControl
+	}
+	return nil
+}
+
+func (x *SignalingEvent) GetVoiceControl() *VoiceControl {
+	if x, ok := x.GetEvent().(*SignalingEvent_VoiceControl); ok {
+		return x.VoiceControl
+	}
+	return nil
+}
+
+type isSignalingEvent_Event interface {
+	isSignalingEvent_Event()
+}
+
+type SignalingEvent_SessionDescription struct {
+	SessionDescription *SessionDescription `protobuf:"bytes,1,opt,name=session_description,json=sessionDescription,proto3,oneof"`
+}
+
+type SignalingEvent_Error struct {
+	Error *SignalingError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+type SignalingEvent_JoinResponse struct {
+	JoinResponse *JoinResponse `protobuf:"bytes,3,opt,name=join_response,json=joinResponse,proto3,oneof"`
+}
+
+type SignalingEvent_RoomControl struct {
+	RoomControl *RoomControl `protobuf:"bytes,5,opt,name=room_control,json=roomControl,proto3,oneof"`
+}
+
+type SignalingEvent_VoiceControl struct {
+	VoiceControl *VoiceControl `protobuf:"bytes,8,opt,name=voice_control,json=voiceControl,proto3,oneof"`
+}
+
+func (*SignalingEvent_SessionDescription) isSignalingEvent_Event() {}
+
+func (*SignalingEvent_Error) isSignalingEvent_Event() {}
+
+func (*SignalingEvent_JoinResponse) isSignalingEvent_Event() {}
+
+func (*SignalingEvent_RoomControl) isSignalingEvent_Event() {}
+
+func (*SignalingEvent_VoiceControl) isSignalingEvent_Event() {}
+
+// Sent by the server when it refuses a request, usually right before closing the WebSocket
+type SignalingError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code   SignalingError_Code `protobuf:"varint,1,opt,name=code,proto3,enum=SignalingError_Code" json:"code,omitempty"`
+	Reason string              `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *SignalingError) Reset() {
+	*x = SignalingError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalingError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingError) ProtoMessage() {}
+
+func (x *SignalingError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingError.ProtoReflect.Descriptor instead.
+func (*SignalingError) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SignalingError) GetCode() SignalingError_Code {
+	if x != nil {
+		return x.Code
+	}
+	return SignalingError_CODE_UNSPECIFIED
+}
+
+func (x *SignalingError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Sent by the server once a new connection has been placed in a room
+type JoinResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Role       Role          `protobuf:"varint,1,opt,name=role,proto3,enum=Role" json:"role,omitempty"`
+	Seat       uint32        `protobuf:"varint,2,opt,name=seat,proto3" json:"seat,omitempty"`                        // 1-based seat of a player, 0 for a spectator
+	ClientId   string        `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // how the room identifies this client
+	HostId     string        `protobuf:"bytes,4,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`       // client id of the room's host
+	Seats      uint32        `protobuf:"varint,5,opt,name=seats,proto3" json:"seats,omitempty"`                      // seats in the game, the offer should receive one voice track per seat
+	Commentary bool          `protobuf:"varint,6,opt,name=commentary,proto3" json:"commentary,omitempty"`            // the offer should also receive the room's commentary track, the game's audio mixed with voice chat
+	Video      *VideoProfile `protobuf:"bytes,7,opt,name=video,proto3" json:"video,omitempty"`                       // how the room's video is captured, for the player to scale it
+}
+
+func (x *JoinResponse) Reset() {
+	*x = JoinResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinResponse) ProtoMessage() {}
+
+func (x *JoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinResponse.ProtoReflect.Descriptor instead.
+func (*JoinResponse) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JoinResponse) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *JoinResponse) GetSeat() uint32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *JoinResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *JoinResponse) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *JoinResponse) GetSeats() uint32 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
+func (x *JoinResponse) GetCommentary() bool {
+	if x != nil {
+		return x.Commentary
+	}
+	return false
+}
+
+func (x *JoinResponse) GetVideo() *VideoProfile {
+	if x != nil {
+		return x.Video
+	}
+	return nil
+}
+
+// How a room's display is captured and encoded, the same for the whole life of the room
+type VideoProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width       uint32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height      uint32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Framerate   uint32 `protobuf:"varint,3,opt,name=framerate,proto3" json:"framerate,omitempty"`
+	BitrateKbps uint32 `protobuf:"varint,4,opt,name=bitrate_kbps,json=bitrateKbps,proto3" json:"bitrate_kbps,omitempty"`
+}
+
+func (x *VideoProfile) Reset() {
+	*x = VideoProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VideoProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoProfile) ProtoMessage() {}
+
+func (x *VideoProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoProfile.ProtoReflect.Descriptor instead.
+func (*VideoProfile) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VideoProfile) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *VideoProfile) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *VideoProfile) GetFramerate() uint32 {
+	if x != nil {
+		return x.Framerate
+	}
+	return 0
+}
+
+func (x *VideoProfile) GetBitrateKbps() uint32 {
+	if x != nil {
+		return x.BitrateKbps
+	}
+	return 0
+}
+
+// Sent by the room's host to manage the room
+type RoomControl struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Control:
+	//	*RoomControl_Kick
+	//	*RoomControl_Ban
+	//	*RoomControl_Lock
+	//	*RoomControl_TransferHost
+	//	*RoomControl_Promote_
+	//	*RoomControl_Demote
+	//	*RoomControl_Swap_
+	//	*RoomControl_SwitchGame
+	//	*RoomControl_Countdown
+	//	*RoomControl_Voice
+	//	*RoomControl_Record
+	//	*RoomControl_Broadcast
+	Control isRoomControl_Control `protobuf_oneof:"Control"`
+}
+
+func (x *RoomControl) Reset() {
+	*x = RoomControl{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomControl) ProtoMessage() {}
+
+func (x *RoomControl) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomControl.ProtoReflect.Descriptor instead.
+func (*RoomControl) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *RoomControl) GetControl() isRoomControl_Control {
+	if m != nil {
+		return m.Control
+	}
+	return nil
+}
+
+func (x *RoomControl) GetKick() string {
+	if x, ok := x.GetControl().(*RoomControl_Kick); ok {
+		return x.Kick
+	}
+	return ""
+}
+
+func (x *RoomControl) GetBan() string {
+	if x, ok := x.GetControl().(*RoomControl_Ban); ok {
+		return x.Ban
+	}
+	return ""
+}
+
+func (x *RoomControl) GetLock() bool {
+	if x, ok := x.GetControl().(*RoomControl_Lock); ok {
+		return x.Lock
+	}
+	return false
+}
+
+func (x *RoomControl) GetTransferHost() string {
+	if x, ok := x.GetControl().(*RoomControl_TransferHost); ok {
+		return x.TransferHost
+	}
+	return ""
+}
+
+func (x *RoomControl) GetPromote() *RoomControl_Promote {
+	if x, ok := x.GetControl().(*RoomControl_Promote_); ok {
+		return x.Promote
+	}
+	return nil
+}
+
+func (x *RoomControl) GetDemote() string {
+	if x, ok := x.GetControl().(*RoomControl_Demote); ok {
+		return x.Demote
+	}
+	return ""
+}
+
+func (x *RoomControl) GetSwap() *RoomControl_Swap {
+	if x, ok := x.GetControl().(*RoomControl_Swap_); ok {
+		return x.Swap
+	}
+	return nil
+}
+
+func (x *RoomControl) GetSwitchGame() string {
+	if x, ok := x.GetControl().(*RoomControl_SwitchGame); ok {
+		return x.SwitchGame
+	}
+	return ""
+}
+
+func (x *RoomControl) GetCountdown() uint32 {
+	if x, ok := x.GetControl().(*RoomControl_Countdown); ok {
+		return x.Countdown
+	}
+	return 0
+}
+
+func (x *RoomControl) GetVoice() bool {
+	if x, ok := x.GetControl().(*RoomControl_Voice); ok {
+		return x.Voice
+	}
+	return false
+}
+
+func (x *RoomControl) GetRecord() bool {
+	if x, ok := x.GetControl().(*RoomControl_Record); ok {
+		return x.Record
+	}
+	return false
+}
+
+func (x *RoomControl) GetBroadcast() string {
+	if x, ok := x.GetControl().(*RoomControl_Broadcast); ok {
+		return x.Broadcast
+	}
+	return ""
+}
+
+type isRoomControl_Control interface {
+	isRoomControl_Control()
+}
+
+type RoomControl_Kick struct {
+	Kick string `protobuf:"bytes,1,opt,name=kick,proto3,oneof"` // client id to remove from the room
+}
+
+type RoomControl_Ban struct {
+	Ban string `protobuf:"bytes,2,opt,name=ban,proto3,oneof"` // client id to remove and keep out for the rest of the room's lifetime
+}
+
+type RoomControl_Lock struct {
+	Lock bool `protobuf:"varint,3,opt,name=lock,proto3,oneof"` // true to refuse new joins, false to accept them again
+}
+
+type RoomControl_TransferHost struct {
+	TransferHost string `protobuf:"bytes,4,opt,name=transfer_host,json=transferHost,proto3,oneof"` // client id of the new host
+}
+
+type RoomControl_Promote_ struct {
+	Promote *RoomControl_Promote `protobuf:"bytes,5,opt,name=promote,proto3,oneof"`
+}
+
+type RoomControl_Demote struct {
+	Demote string `protobuf:"bytes,6,opt,name=demote,proto3,oneof"` // client id of a player to move to the spectators
+}
+
+type RoomControl_Swap_ struct {
+	Swap *RoomControl_Swap `protobuf:"bytes,7,opt,name=swap,proto3,oneof"`
+}
+
+type RoomControl_SwitchGame struct {
+	SwitchGame string `protobuf:"bytes,8,opt,name=switch_game,json=switchGame,proto3,oneof"` // game id of the game to play next
+}
+
+type RoomControl_Countdown struct {
+	Countdown uint32 `protobuf:"varint,9,opt,name=countdown,proto3,oneof"` // seconds to count down from, 0 to cancel a running countdown
+}
+
+type RoomControl_Voice struct {
+	Voice bool `protobuf:"varint,10,opt,name=voice,proto3,oneof"` // false to turn voice chat off for everyone, true to turn it back on
+}
+
+type RoomControl_Record struct {
+	Record bool `protobuf:"varint,11,opt,name=record,proto3,oneof"` // true to start recording the room's audio and video to disk, false to stop
+}
+
+type RoomControl_Broadcast struct {
+	Broadcast string `protobuf:"bytes,12,opt,name=broadcast,proto3,oneof"` // rtmp url, with the stream key, to push the room's audio and video to, empty to stop
+}
+
+func (*RoomControl_Kick) isRoomControl_Control() {}
+
+func (*RoomControl_Ban) isRoomControl_Control() {}
+
+func (*RoomControl_Lock) isRoomControl_Control() {}
+
+func (*RoomControl_TransferHost) isRoomControl_Control() {}
+
+func (*RoomControl_Promote_) isRoomControl_Control() {}
+
+func (*RoomControl_Demote) isRoomControl_Control() {}
+
+func (*RoomControl_Swap_) isRoomControl_Control() {}
+
+func (*RoomControl_SwitchGame) isRoomControl_Control() {}
+
+func (*RoomControl_Countdown) isRoomControl_Control() {}
+
+func (*RoomControl_Voice) isRoomControl_Control() {}
+
+func (*RoomControl_Record) isRoomControl_Control() {}
+
+func (*RoomControl_Broadcast) isRoomControl_Control() {}
+
+// Sent by any client to control its own voice chat
+type VoiceControl struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Control:
+	//	*VoiceControl_Mute
+	//	*VoiceControl_Unmute
+	//	*VoiceControl_Talking
+	Control isVoiceControl_Control `protobuf_oneof:"Control"`
+}
+
+func (x *VoiceControl) Reset() {
+	*x = VoiceControl{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoiceControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceControl) ProtoMessage() {}
+
+func (x *VoiceControl) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceControl.ProtoReflect.Descriptor instead.
+func (*VoiceControl) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *VoiceControl) GetControl() isVoiceControl_Control {
+	if m != nil {
+		return m.Control
+	}
+	return nil
+}
+
+func (x *VoiceControl) GetMute() string {
+	if x, ok := x.GetControl().(*VoiceControl_Mute); ok {
+		return x.Mute
+	}
+	return ""
+}
+
+func (x *VoiceControl) GetUnmute() string {
+	if x, ok := x.GetControl().(*VoiceControl_Unmute); ok {
+		return x.Unmute
+	}
+	return ""
+}
+
+func (x *VoiceControl) GetTalking() bool {
+	if x, ok := x.GetControl().(*VoiceControl_Talking); ok {
+		return x.Talking
+	}
+	return false
+}
+
+type isVoiceControl_Control interface {
+	isVoiceControl_Control()
+}
+
+type VoiceControl_Mute struct {
+	Mute string `protobuf:"bytes,1,opt,name=mute,proto3,oneof"` // client id of a player to stop hearing
+}
+
+type VoiceControl_Unmute struct {
+	Unmute string `protobuf:"bytes,2,opt,name=unmute,proto3,oneof"` // client id of a player to hear again
+}
+
+type VoiceControl_Talking struct {
+	Talking bool `protobuf:"varint,3,opt,name=talking,proto3,oneof"` // push-to-talk, a player's microphone is only forwarded while they are talking
+}
+
+func (*VoiceControl_Mute) isVoiceControl_Control() {}
+
+func (*VoiceControl_Unmute) isVoiceControl_Control() {}
+
+func (*VoiceControl_Talking) isVoiceControl_Control() {}
+
+type RoomControl_Promote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Seat     uint32 `protobuf:"varint,2,opt,name=seat,proto3" json:"seat,omitempty"` // 0 for any free seat
+}
+
+func (x *RoomControl_Promote) Reset() {
+	*x = RoomControl_Promote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomControl_Promote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomControl_Promote) ProtoMessage() {}
+
+func (x *RoomControl_Promote) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomControl_Promote.ProtoReflect.Descriptor instead.
+func (*RoomControl_Promote) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *RoomControl_Promote) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RoomControl_Promote) GetSeat() uint32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+type RoomControl_Swap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeatA uint32 `protobuf:"varint,1,opt,name=seat_a,json=seatA,proto3" json:"seat_a,omitempty"`
+	SeatB uint32 `protobuf:"varint,2,opt,name=seat_b,json=seatB,proto3" json:"seat_b,omitempty"`
+}
+
+func (x *RoomControl_Swap) Reset() {
+	*x = RoomControl_Swap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_signaling_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomControl_Swap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomControl_Swap) ProtoMessage() {}
+
+func (x *RoomControl_Swap) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_signaling_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomControl_Swap.ProtoReflect.Descriptor instead.
+func (*RoomControl_Swap) Descriptor() ([]byte, []int) {
+	return file_proto_signaling_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *RoomControl_Swap) GetSeatA() uint32 {
+	if x != nil {
+		return x.SeatA
+	}
+	return 0
+}
+
+func (x *RoomControl_Swap) GetSeatB() uint32 {
+	if x != nil {
+		return x.SeatB
+	}
+	return 0
+}
+
+var File_proto_signaling_proto protoreflect.FileDescriptor
+
+var file_proto_signaling_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8b, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x53, 0x44, 0x50, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x64, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x64,
+	0x70, 0x22, 0xb1, 0x01, 0x0a, 0x07, 0x53, 0x44, 0x50, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
+	0x14, 0x53, 0x44, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x6f, 0x66, 0x66, 0x65, 0x72,
+	0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x44, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4f,
+	0x46, 0x46, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x70, 0x72, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x44, 0x50, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x50, 0x52, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x44, 0x50, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08,
+	0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x44,
+	0x50, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x10,
+	0x04, 0x1a, 0x02, 0x10, 0x01, 0x22, 0xbb, 0x02, 0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x46, 0x0a, 0x13, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x12, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x27, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x0d, 0x6a, 0x6f, 0x69,
+	0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x0c, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x31, 0x0a, 0x0c, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x12, 0x34, 0x0a, 0x0d, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x56, 0x6f, 0x69, 0x63,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x0c, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x42, 0x07, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x4a, 0x04, 0x08,
+	0x07, 0x10, 0x08, 0x22, 0xc1, 0x04, 0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e,
+	0x67, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xec, 0x03, 0x0a, 0x04, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x47, 0x41, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x12,
+	0x0a, 0x0e, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x41, 0x58, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x53,
+	0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x5f,
+	0x46, 0x55, 0x4c, 0x4c, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x53,
+	0x45, 0x41, 0x54, 0x53, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x43, 0x54, 0x41, 0x54, 0x4f, 0x52, 0x53, 0x5f, 0x46,
+	0x55, 0x4c, 0x4c, 0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e,
+	0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x53, 0x45, 0x41, 0x54, 0x10, 0x06, 0x12, 0x17, 0x0a, 0x13,
+	0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43,
+	0x54, 0x45, 0x44, 0x10, 0x07, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f,
+	0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x08, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x44,
+	0x45, 0x5f, 0x53, 0x45, 0x41, 0x54, 0x5f, 0x54, 0x41, 0x4b, 0x45, 0x4e, 0x10, 0x09, 0x12, 0x11,
+	0x0a, 0x0d, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x48, 0x4f, 0x53, 0x54, 0x10,
+	0x0a, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x4c,
+	0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x42, 0x41, 0x4e, 0x4e, 0x45, 0x44, 0x10, 0x0c, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x4f, 0x44, 0x45,
+	0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x45, 0x44, 0x10,
+	0x0d, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4b, 0x49, 0x43, 0x4b, 0x45, 0x44,
+	0x10, 0x0e, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x10, 0x0f, 0x12, 0x19, 0x0a, 0x15, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x4f, 0x4f, 0x5f,
+	0x4c, 0x4f, 0x4e, 0x47, 0x10, 0x10, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52,
+	0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x10, 0x11, 0x12, 0x1e, 0x0a,
+	0x1a, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f,
+	0x55, 0x4e, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x12, 0x12, 0x1e, 0x0a,
+	0x1a, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x42, 0x52, 0x4f, 0x41, 0x44, 0x43, 0x41, 0x53, 0x54, 0x5f,
+	0x55, 0x4e, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x13, 0x12, 0x18, 0x0a,
+	0x14, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x50, 0x52,
+	0x4f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x14, 0x22, 0xce, 0x01, 0x0a, 0x0c, 0x4a, 0x6f, 0x69, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x05, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x73, 0x65, 0x61, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x72,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x52, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x22, 0x7d, 0x0a, 0x0c, 0x56, 0x69, 0x64, 0x65,
+	0x6f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x72, 0x61, 0x6d, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x5f,
+	0x6b, 0x62, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x69, 0x74, 0x72,
+	0x61, 0x74, 0x65, 0x4b, 0x62, 0x70, 0x73, 0x22, 0xfb, 0x03, 0x0a, 0x0b, 0x52, 0x6f, 0x6f, 0x6d,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x04, 0x6b, 0x69, 0x63, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x63, 0x6b, 0x12, 0x12, 0x0a,
+	0x03, 0x62, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x62, 0x61,
+	0x6e, 0x12, 0x14, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x00, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x25, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x30,
+	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2e, 0x50, 0x72,
+	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65,
+	0x12, 0x18, 0x0a, 0x06, 0x64, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x77,
+	0x61, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x48, 0x00, 0x52, 0x04, 0x73,
+	0x77, 0x61, 0x70, 0x12, 0x21, 0x0a, 0x0b, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x5f, 0x67, 0x61,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x77, 0x69, 0x74,
+	0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x64,
+	0x6f, 0x77, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x16, 0x0a, 0x05, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x05, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x18,
+	0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
+	0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1e, 0x0a, 0x09, 0x62, 0x72, 0x6f, 0x61,
+	0x64, 0x63, 0x61, 0x73, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x62,
+	0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x1a, 0x3a, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x73, 0x65, 0x61, 0x74, 0x1a, 0x34, 0x0a, 0x04, 0x53, 0x77, 0x61, 0x70, 0x12, 0x15, 0x0a, 0x06,
+	0x73, 0x65, 0x61, 0x74, 0x5f, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x65,
+	0x61, 0x74, 0x41, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x74, 0x5f, 0x62, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74, 0x42, 0x42, 0x09, 0x0a, 0x07, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x22, 0x65, 0x0a, 0x0c, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x04, 0x6d, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x75, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x75,
+	0x6e, 0x6d, 0x75, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x75,
+	0x6e, 0x6d, 0x75, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x07, 0x74, 0x61, 0x6c, 0x6b, 0x69, 0x6e, 0x67,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x74, 0x61, 0x6c, 0x6b, 0x69, 0x6e,
+	0x67, 0x42, 0x09, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2a, 0x41, 0x0a, 0x04,
+	0x52, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f,
+	0x4c, 0x45, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x52,
+	0x4f, 0x4c, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x43, 0x54, 0x41, 0x54, 0x4f, 0x52, 0x10, 0x02, 0x42,
+	0x12, 0x5a, 0x10, 0x7a, 0x6f, 0x6f, 0x6d, 0x67, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_signaling_proto_rawDescOnce sync.Once
+	file_proto_signaling_proto_rawDescData = file_proto_signaling_proto_rawDesc
+)
+
+func file_proto_signaling_proto_rawDescGZIP() []byte {
+	file_proto_signaling_proto_rawDescOnce.Do(func() {
+		file_proto_signaling_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_signaling_proto_rawDescData)
+	})
+	return file_proto_signaling_proto_rawDescData
+}
+
+var file_proto_signaling_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_signaling_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_signaling_proto_goTypes = []interface{}{
+	(Role)(0),                       // 0: Role
+	(SessionDescription_SDPType)(0), // 1: SessionDescription.SDPType
+	(SignalingError_Code)(0),        // 2: SignalingError.Code
+	(*SessionDescription)(nil),      // 3: SessionDescription
+	(*SignalingEvent)(nil),          // 4: SignalingEvent
+	(*SignalingError)(nil),          // 5: SignalingError
+	(*JoinResponse)(nil),            // 6: JoinResponse
+	(*VideoProfile)(nil),            // 7: VideoProfile
+	(*RoomControl)(nil),             // 8: RoomControl
+	(*VoiceControl)(nil),            // 9: VoiceControl
+	(*RoomControl_Promote)(nil),     // 10: RoomControl.Promote
+	(*RoomControl_Swap)(nil),        // 11: RoomControl.Swap
+}
+var file_proto_signaling_proto_depIdxs = []int32{
+	1,  // 0: SessionDescription.type:type_name -> SessionDescription.SDPType
+	3,  // 1: SignalingEvent.session_description:type_name -> SessionDescription
+	5,  // 2: SignalingEvent.error:type_name -> SignalingError
+	6,  // 3: SignalingEvent.join_response:type_name -> JoinResponse
+	8,  // 4: SignalingEvent.room_control:type_name -> RoomControl
+	9,  // 5: SignalingEvent.voice_control:type_name -> VoiceControl
+	2,  // 6: SignalingError.code:type_name -> SignalingError.Code
+	0,  // 7: JoinResponse.role:type_name -> Role
+	7,  // 8: JoinResponse.video:type_name -> VideoProfile
+	10, // 9: RoomControl.promote:type_name -> RoomControl.Promote
+	11, // 10: RoomControl.swap:type_name -> RoomControl.Swap
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_proto_signaling_proto_init() }
+func file_proto_signaling_proto_init() {
+	if File_proto_signaling_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_signaling_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionDescription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalingEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalingError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VideoProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomControl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoiceControl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomControl_Promote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_signaling_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomControl_Swap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_signaling_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*SignalingEvent_SessionDescription)(nil),
+		(*SignalingEvent_Error)(nil),
+		(*SignalingEvent_JoinResponse)(nil),
+		(*SignalingEvent_RoomControl)(nil),
+		(*SignalingEvent_VoiceControl)(nil),
+	}
+	file_proto_signaling_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*RoomControl_Kick)(nil),
+		(*RoomControl_Ban)(nil),
+		(*RoomControl_Lock)(nil),
+		(*RoomControl_TransferHost)(nil),
+		(*RoomControl_Promote_)(nil),
+		(*RoomControl_Demote)(nil),
+		(*RoomControl_Swap_)(nil),
+		(*RoomControl_SwitchGame)(nil),
+		(*RoomControl_Countdown)(nil),
+		(*RoomControl_Voice)(nil),
+		(*RoomControl_Record)(nil),
+		(*RoomControl_Broadcast)(nil),
+	}
+	file_proto_signaling_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*VoiceControl_Mute)(nil),
+		(*VoiceControl_Unmute)(nil),
+		(*VoiceControl_Talking)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_signaling_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   1,
+			NumEnums:      3,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
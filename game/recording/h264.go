@@ -0,0 +1,72 @@
+package recording
+
+import (
+	"io"
+
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+)
+
+// NAL unit types
+const (
+	naluIDR  = 5
+	naluSPS  = 7
+	naluSTAP = 24
+	naluFU   = 28
+)
+
+// Whether an H264 rtp payload starts a keyframe, i.e. carries the sequence parameter set or the start of an IDR slice
+func IsKeyFrame(payload []byte) bool {
+
+	if len(payload) < 1 {
+		return false
+	}
+
+	switch typ := payload[0] & 0x1f; typ {
+	case naluIDR, naluSPS:
+		return true
+	case naluSTAP:
+		// 2 bytes of size before each aggregated unit
+		for i := 1; i+2 < len(payload); {
+			size := int(payload[i])<<8 | int(payload[i+1])
+			if t := payload[i+2] & 0x1f; t == naluIDR || t == naluSPS {
+				return true
+			}
+			i += 2 + size
+		}
+		return false
+	case naluFU:
+		// the start bit of the fragment header, and the type of the fragmented unit
+		return len(payload) > 1 && payload[1]&0x80 != 0 && (payload[1]&0x1f == naluIDR || payload[1]&0x1f == naluSPS)
+	default:
+		return false
+	}
+}
+
+// Writes H264 rtp packets as an Annex-B elementary stream, starting from the first keyframe
+type h264Writer struct {
+	w       io.Writer
+	started bool
+}
+
+func newH264Writer(w io.Writer) *h264Writer {
+	return &h264Writer{w: w}
+}
+
+func (h *h264Writer) WriteRTP(pkt *rtp.Packet) error {
+
+	if !h.started {
+		if h.started = IsKeyFrame(pkt.Payload); !h.started {
+			return nil
+		}
+	}
+
+	// fragments are written as they come, each unit's start code goes before its first fragment
+	data, err := (&codecs.H264Packet{}).Unmarshal(pkt.Payload)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	_, err = h.w.Write(data)
+	return err
+}
@@ -0,0 +1,251 @@
+package recording
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3/pkg/media/oggwriter"
+	"go.uber.org/zap"
+)
+
+/**
+
+Recordings of a room's audio and video, written to disk as the room streams them
+
+A recorder is fed the same RTP packets as the room's tracks, and depacketizes them into
+an H264 Annex-B file for the video and an Ogg Opus file for the audio, e.g. to be muxed with
+	ffmpeg -i video.h264 -i audio.ogg -c copy session.mp4
+
+Each room records into its own directory, and each recording session is described by a JSON file next to its media.
+Clips of instant replays are saved the same way, from a replay buffer, see replay.go.
+
+*/
+
+// A recording session
+type Recording struct {
+	ID        string // unique within the room, from the time recording started
+	Room      string // id of the room that was recorded
+	StartedAt time.Time
+	StoppedAt time.Time // zero while recording, or if the server stopped without finishing the recording
+	Video     string    // file name of the video, in the room's directory
+	Audio     string    // file name of the audio, in the room's directory
+	Clip      bool      // an instant replay, saved from the room's replay buffer
+}
+
+func (r Recording) Active() bool {
+	return r.StoppedAt.IsZero()
+}
+
+type Recorder interface {
+	WriteVideo([]byte) // an H264 rtp packet
+	WriteAudio([]byte) // an Opus rtp packet
+	Recording() Recording
+	Stop() (Recording, error)
+}
+
+type recorder struct {
+	mu      *sync.Mutex
+	dir     string // the room's directory
+	rec     Recording
+	video   *os.File
+	h264    *h264Writer
+	ogg     *oggwriter.OggWriter
+	stopped bool
+	failed  map[string](bool) // tracks that failed to write, to only log the first error
+	log     *zap.Logger
+}
+
+const (
+	timeFormat = "20060102-150405.000"
+	clipPrefix = "clip-"
+)
+
+// Start recording a room into its directory under dir
+func NewRecorder(dir string, room string, log *zap.Logger) (Recorder, error) {
+	return newRecorder(dir, room, "", log)
+}
+
+func newRecorder(dir string, room string, prefix string, log *zap.Logger) (r *recorder, err error) {
+
+	roomDir, err := RoomDir(dir, room)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(roomDir, 0755); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rec := Recording{
+		ID:        prefix + now.UTC().Format(timeFormat),
+		Room:      room,
+		StartedAt: now,
+		Clip:      prefix == clipPrefix,
+	}
+	rec.Video = rec.ID + ".h264"
+	rec.Audio = rec.ID + ".ogg"
+
+	video, err := os.Create(filepath.Join(roomDir, rec.Video))
+	if err != nil {
+		return nil, err
+	}
+
+	ogg, err := oggwriter.New(filepath.Join(roomDir, rec.Audio), 48000, 2)
+	if err != nil {
+		video.Close()
+		return nil, err
+	}
+
+	rr := &recorder{
+		mu:     &sync.Mutex{},
+		dir:    roomDir,
+		rec:    rec,
+		video:  video,
+		h264:   newH264Writer(video),
+		ogg:    ogg,
+		failed: make(map[string](bool)),
+		log:    log.With(zap.String("recording", rec.ID)),
+	}
+
+	if err = rr.save(); err != nil {
+		video.Close()
+		ogg.Close()
+		return nil, err
+	}
+
+	return rr, nil
+}
+
+func (r *recorder) WriteVideo(pckt []byte) {
+	r.write("video", pckt, r.h264.WriteRTP)
+}
+
+func (r *recorder) WriteAudio(pckt []byte) {
+	r.write("audio", pckt, r.ogg.WriteRTP)
+}
+
+func (r *recorder) write(track string, pckt []byte, write func(*rtp.Packet) error) {
+
+	p := &rtp.Packet{}
+	if err := p.Unmarshal(pckt); err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return
+	}
+
+	if err := write(p); err != nil && !r.failed[track] {
+		r.failed[track] = true
+		r.log.Warn("Error recording", zap.String("track", track), zap.Error(err))
+	}
+}
+
+func (r *recorder) Recording() Recording {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rec
+}
+
+// Finish writing the media files, stopping a recorder that already stopped does nothing
+func (r *recorder) Stop() (Recording, error) {
+	return r.stop(time.Now())
+}
+
+func (r *recorder) stop(at time.Time) (Recording, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return r.rec, nil
+	}
+	r.stopped = true
+	r.rec.StoppedAt = at
+
+	errVideo := r.video.Close()
+	errAudio := r.ogg.Close()
+	errSave := r.save()
+
+	for _, err := range []error{errVideo, errAudio, errSave} {
+		if err != nil {
+			return r.rec, err
+		}
+	}
+	return r.rec, nil
+}
+
+// Write the recording's description next to its media, must hold r.mu
+func (r *recorder) save() error {
+
+	b, err := json.MarshalIndent(r.rec, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(r.dir, r.rec.ID+".json"), b, 0644)
+}
+
+// The directory of a room's recordings, refusing room ids that would lead outside dir
+func RoomDir(dir string, room string) (string, error) {
+
+	if room == "" || room == "." || room == ".." || strings.ContainsAny(room, `/\`) {
+		return "", errors.New(fmt.Sprintf("invalid room id for a directory: %q", room))
+	}
+
+	return filepath.Join(dir, room), nil
+}
+
+// The recordings of a room, oldest first
+func List(dir string, room string) ([]Recording, error) {
+
+	roomDir, err := RoomDir(dir, room)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(roomDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	recordings := make([]Recording, 0, len(files))
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var rec Recording
+		if err := json.Unmarshal(b, &rec); err != nil {
+			continue // not a recording
+		}
+		recordings = append(recordings, rec)
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].StartedAt.Before(recordings[j].StartedAt)
+	})
+
+	return recordings, nil
+}
+
+// Where the HTTP server serves a file of a room's recordings
+func FileURL(room string, file string) string {
+	return "/recordings/" + url.PathEscape(room) + "/" + url.PathEscape(file)
+}
@@ -0,0 +1,126 @@
+package recording
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"go.uber.org/zap"
+)
+
+/**
+
+A rolling buffer of the last seconds of a room's video and audio, for instant replays
+
+The buffer always starts on a keyframe, so it holds somewhat more than its length:
+everything since the last keyframe before the length.
+
+*/
+
+type Replay interface {
+	WriteVideo([]byte) // an H264 rtp packet
+	WriteAudio([]byte) // an Opus rtp packet
+	Save(dir string, room string, log *zap.Logger) (Recording, error)
+}
+
+type replayPacket struct {
+	at    time.Time
+	video bool
+	key   bool // starts a keyframe
+	pckt  []byte
+}
+
+type replay struct {
+	mu      *sync.Mutex
+	length  time.Duration
+	packets []replayPacket // oldest first
+}
+
+// Packets older than this many lengths are dropped even if no keyframe came since, to bound the buffer
+const maxReplayLengths = 3
+
+func NewReplay(length time.Duration) Replay {
+	return &replay{
+		mu:     &sync.Mutex{},
+		length: length,
+	}
+}
+
+func (r *replay) WriteVideo(pckt []byte) {
+
+	p := &rtp.Packet{}
+	if err := p.Unmarshal(pckt); err != nil {
+		return
+	}
+
+	r.write(replayPacket{video: true, key: IsKeyFrame(p.Payload), pckt: pckt})
+}
+
+func (r *replay) WriteAudio(pckt []byte) {
+	r.write(replayPacket{pckt: pckt})
+}
+
+func (r *replay) write(p replayPacket) {
+
+	p.at = time.Now()
+	p.pckt = append([]byte(nil), p.pckt...) // the caller may reuse its buffer
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.packets = append(r.packets, p)
+	r.trim(p.at)
+}
+
+// Drop everything before the last keyframe that is older than the length, must hold r.mu
+func (r *replay) trim(now time.Time) {
+
+	cutoff := now.Add(-r.length)
+	hardCutoff := now.Add(-maxReplayLengths * r.length)
+
+	start := 0
+	for i, p := range r.packets {
+		if p.at.After(cutoff) {
+			break
+		}
+		if p.key {
+			start = i
+		}
+	}
+	for start < len(r.packets) && r.packets[start].at.Before(hardCutoff) {
+		start++
+	}
+
+	if start > 0 {
+		r.packets = append(r.packets[:0:0], r.packets[start:]...)
+	}
+}
+
+// Write what is in the buffer out as a clip, in a room's directory under dir
+func (r *replay) Save(dir string, room string, log *zap.Logger) (Recording, error) {
+
+	r.mu.Lock()
+	packets := append([]replayPacket(nil), r.packets...)
+	r.mu.Unlock()
+
+	rec, err := newRecorder(dir, room, clipPrefix, log)
+	if err != nil {
+		return Recording{}, err
+	}
+
+	stoppedAt := time.Now()
+	if len(packets) > 0 {
+		rec.rec.StartedAt = packets[0].at
+		stoppedAt = packets[len(packets)-1].at
+	}
+
+	for _, p := range packets {
+		if p.video {
+			rec.WriteVideo(p.pckt)
+		} else {
+			rec.WriteAudio(p.pckt)
+		}
+	}
+
+	return rec.stop(stoppedAt)
+}
@@ -0,0 +1,50 @@
+package recording
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplayTrim(t *testing.T) {
+
+	now := time.Now()
+	at := func(ago int) time.Time { return now.Add(-time.Duration(ago) * time.Second) }
+
+	tests := []struct {
+		name    string
+		packets []replayPacket
+		kept    int // packets left at the end of the buffer
+	}{
+		{"empty", nil, 0},
+		{"all recent", []replayPacket{{at: at(5), key: true}, {at: at(4)}, {at: at(1)}}, 3},
+		{
+			"from the last keyframe before the length",
+			[]replayPacket{{at: at(25), key: true}, {at: at(15), key: true}, {at: at(12)}, {at: at(9)}, {at: at(5), key: true}, {at: at(1)}},
+			5,
+		},
+		{
+			"a keyframe within the length does not trim what it needs",
+			[]replayPacket{{at: at(15), key: true}, {at: at(12)}, {at: at(5), key: true}, {at: at(1)}},
+			4,
+		},
+		{
+			"no keyframe for more than the bound",
+			[]replayPacket{{at: at(40), key: true}, {at: at(31)}, {at: at(29)}, {at: at(1)}},
+			2,
+		},
+	}
+
+	for _, test := range tests {
+		r := NewReplay(10 * time.Second).(*replay)
+		r.packets = append(r.packets, test.packets...)
+		r.trim(now)
+
+		if len(r.packets) != test.kept {
+			t.Errorf("%s: kept %d packets, want %d", test.name, len(r.packets), test.kept)
+			continue
+		}
+		if test.kept > 0 && r.packets[len(r.packets)-1].at != test.packets[len(test.packets)-1].at {
+			t.Errorf("%s: dropped the latest packet", test.name)
+		}
+	}
+}
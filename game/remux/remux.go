@@ -0,0 +1,98 @@
+package remux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+)
+
+/**
+
+RTP of a room forwarded to a local ffmpeg, to repackage the room's streams without transcoding
+
+The input is fed the same RTP packets as the room's tracks, and sends them on to two ports,
+described by an sdp file that ffmpeg reads as its input. ffmpeg also listens for rtcp on the port
+after each, so an input takes four ports. Packets are dropped until ffmpeg listens, and after it exits.
+
+*/
+
+const (
+	videoPayload = 96 // payload types the sdp declares, whatever the encoders use
+	audioPayload = 111
+)
+
+type Input struct {
+	sdp   string
+	video *net.UDPConn
+	audio *net.UDPConn
+}
+
+// Forward to ports from videoPort up, describing them in an sdp file in dir
+func NewInput(dir string, videoPort int) (*Input, error) {
+
+	audioPort := videoPort + 2
+
+	sdp := filepath.Join(dir, "stream.sdp")
+	err := ioutil.WriteFile(sdp, []byte(fmt.Sprintf(sdpFormat, videoPort, videoPayload, videoPayload, videoPayload,
+		audioPort, audioPayload, audioPayload)), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	video, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: videoPort})
+	if err != nil {
+		return nil, err
+	}
+	audio, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: audioPort})
+	if err != nil {
+		video.Close()
+		return nil, err
+	}
+
+	return &Input{sdp: sdp, video: video, audio: audio}, nil
+}
+
+const sdpFormat = `v=0
+o=- 0 0 IN IP4 127.0.0.1
+s=zoomgaming
+c=IN IP4 127.0.0.1
+t=0 0
+m=video %d RTP/AVP %d
+a=rtpmap:%d H264/90000
+a=fmtp:%d packetization-mode=1
+m=audio %d RTP/AVP %d
+a=rtpmap:%d opus/48000/2
+`
+
+// The ffmpeg arguments that read the input
+func (i *Input) Args() []string {
+	return []string{"-protocol_whitelist", "file,udp,rtp", "-fflags", "+genpts", "-i", i.sdp}
+}
+
+func (i *Input) WriteVideo(pckt []byte) {
+	forward(i.video, pckt, videoPayload)
+}
+
+func (i *Input) WriteAudio(pckt []byte) {
+	forward(i.audio, pckt, audioPayload)
+}
+
+func (i *Input) Close() {
+	i.video.Close()
+	i.audio.Close()
+}
+
+func forward(conn *net.UDPConn, pckt []byte, payloadType byte) {
+
+	if len(pckt) < 12 {
+		return
+	}
+
+	// The packet is shared with the room's tracks, rewrite the payload type on a copy
+	out := make([]byte, len(pckt))
+	copy(out, pckt)
+	out[1] = out[1]&0x80 | payloadType
+
+	conn.Write(out)
+}
@@ -0,0 +1,88 @@
+package remux
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Two udp listeners two ports apart, as ffmpeg would listen for an input's video and audio
+func listenPair(t *testing.T) (int, *net.UDPConn, *net.UDPConn) {
+	t.Helper()
+
+	for i := 0; i < 20; i++ {
+		video, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		port := video.LocalAddr().(*net.UDPAddr).Port
+		audio, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: port + 2})
+		if err == nil {
+			return port, video, audio
+		}
+		video.Close()
+	}
+	t.Fatal("no free pair of udp ports")
+	return 0, nil, nil
+}
+
+func read(t *testing.T, conn *net.UDPConn) []byte {
+	t.Helper()
+
+	buf := make([]byte, 1500)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf[:n]
+}
+
+func TestInput(t *testing.T) {
+
+	port, video, audio := listenPair(t)
+	defer video.Close()
+	defer audio.Close()
+
+	dir := t.TempDir()
+	input, err := NewInput(dir, port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer input.Close()
+
+	args := input.Args()
+	if args[len(args)-2] != "-i" || !strings.HasPrefix(args[len(args)-1], dir) {
+		t.Errorf("args %v do not read the sdp file in %s", args, dir)
+	}
+	sdp, err := ioutil.ReadFile(args[len(args)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{fmt.Sprintf("m=video %d RTP/AVP 96", port), "a=rtpmap:96 H264/90000", fmt.Sprintf("m=audio %d RTP/AVP 111", port+2), "a=rtpmap:111 opus/48000/2"} {
+		if !bytes.Contains(sdp, []byte(line+"\n")) {
+			t.Errorf("sdp has no line %q:\n%s", line, sdp)
+		}
+	}
+
+	// The payload type is rewritten on a copy, the marker bit is kept
+	pckt := []byte{0x80, 0x80 | 102, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1, 0x65}
+	orig := append([]byte(nil), pckt...)
+	input.WriteVideo(pckt)
+	if got := read(t, video); got[1] != 0x80|96 || !bytes.Equal(got[2:], orig[2:]) {
+		t.Errorf("forwarded video % x, want payload type 96 with the marker bit", got)
+	}
+	if !bytes.Equal(pckt, orig) {
+		t.Error("the caller's packet was modified")
+	}
+
+	input.WriteAudio([]byte{0x80}) // too short to be rtp, dropped
+	input.WriteAudio([]byte{0x80, 97, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1, 0xf8})
+	if got := read(t, audio); len(got) != 13 || got[1] != 111 {
+		t.Errorf("forwarded audio % x, want the rtp packet with payload type 111", got)
+	}
+}
@@ -0,0 +1,135 @@
+package room
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	pb "zoomgaming/proto"
+	"zoomgaming/rtmp"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+The host may push the room's audio and video to an RTMP ingest, e.g. to a broadcaster for a tournament
+
+The server decides which ingests rooms may be pushed to, as url prefixes, so that hosts cannot make
+the server send streams anywhere. A url is allowed if its scheme and host are those of a prefix, and
+its path is the prefix's path or below it. The push reconnects by itself until the host stops it or the room
+shuts down, and its status is part of the room's stats.
+
+*/
+
+// Push the room to an rtmp url, in place of any push that is already running
+func (r *room) StartBroadcast(url string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.broadcastAllowed(url) {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_BROADCAST_UNAVAILABLE, "the server does not push rooms to %s", rtmp.Redact(url))
+	}
+
+	r.stopBroadcast()
+
+	broadcast, err := rtmp.NewBroadcast(url, r.index, r.log)
+	if err != nil {
+		r.log.Error("Error starting RTMP push", zap.String("rtmp", rtmp.Redact(url)), zap.Error(err))
+		return rtc.NewSignalingError(pb.SignalingError_CODE_BROADCAST_UNAVAILABLE, "could not push to %s", rtmp.Redact(url))
+	}
+
+	r.recMu.Lock()
+	r.broadcast = broadcast
+	r.recMu.Unlock()
+
+	r.log.Info("Started RTMP push", zap.String("rtmp", rtmp.Redact(url)))
+	return nil
+}
+
+func (r *room) StopBroadcast() error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.stopBroadcast() {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "the room is not being pushed")
+	}
+	return nil
+}
+
+// Stop the push if there is one, must hold r.mu
+func (r *room) stopBroadcast() bool {
+
+	r.recMu.Lock()
+	broadcast := r.broadcast
+	r.broadcast = nil
+	r.recMu.Unlock()
+
+	if broadcast == nil {
+		return false
+	}
+
+	broadcast.Stop()
+	status := broadcast.Status()
+	r.log.Info("Stopped RTMP push", zap.String("rtmp", status.URL), zap.Int("reconnects", status.Reconnects))
+
+	return true
+}
+
+// Must hold r.mu
+func (r *room) broadcastAllowed(rawURL string) bool {
+	for _, prefix := range r.cfg.BroadcastURLs {
+		if urlUnder(rawURL, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether a url has the scheme and host of a prefix, and a path at or below the prefix's path
+func urlUnder(rawURL string, prefix string) bool {
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	p, err := url.Parse(prefix)
+	if err != nil || p.Host == "" {
+		return false
+	}
+
+	if !strings.EqualFold(u.Scheme, p.Scheme) || !strings.EqualFold(u.Host, p.Host) {
+		return false
+	}
+
+	dir := strings.TrimSuffix(path.Clean("/"+p.Path), "/")
+	clean := path.Clean("/" + u.Path) // so that .. cannot climb out of the prefix
+	return clean == dir || strings.HasPrefix(clean, dir+"/")
+}
+
+// Pass a packet of the game's streams on to the push, if the room is being pushed
+func (r *room) push(pckt []byte, write func(rtmp.Broadcast, []byte)) {
+
+	r.recMu.Lock()
+	defer r.recMu.Unlock()
+
+	if r.broadcast != nil {
+		write(r.broadcast, pckt)
+	}
+}
+
+// Nil unless the room is being pushed, must hold r.mu
+func (r *room) broadcastStatus() *rtmp.Status {
+
+	r.recMu.Lock()
+	defer r.recMu.Unlock()
+
+	if r.broadcast == nil {
+		return nil
+	}
+	status := r.broadcast.Status()
+	return &status
+}
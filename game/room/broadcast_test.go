@@ -0,0 +1,32 @@
+package room
+
+import "testing"
+
+func TestURLUnder(t *testing.T) {
+
+	tests := []struct {
+		url    string
+		prefix string
+		want   bool
+	}{
+		{"rtmp://ingest.example.com/live/key", "rtmp://ingest.example.com", true},
+		{"rtmp://ingest.example.com/live/key", "rtmp://ingest.example.com/live", true},
+		{"rtmp://ingest.example.com/live/key", "rtmp://ingest.example.com/live/", true},
+		{"rtmp://INGEST.example.com/live/key", "rtmp://ingest.example.com/live", true},
+		{"rtmp://ingest.example.com/live", "rtmp://ingest.example.com/live", true},
+		{"rtmp://ingest.example.com.attacker.net/live/key", "rtmp://ingest.example.com", false},
+		{"rtmp://ingest.example.com@attacker.net/live/key", "rtmp://ingest.example.com", false},
+		{"rtmp://ingest.example.com:1936/live/key", "rtmp://ingest.example.com", false},
+		{"rtmps://ingest.example.com/live/key", "rtmp://ingest.example.com", false},
+		{"rtmp://ingest.example.com/livestream/key", "rtmp://ingest.example.com/live", false},
+		{"rtmp://ingest.example.com/live/../other/key", "rtmp://ingest.example.com/live", false},
+		{"/live/key", "rtmp://ingest.example.com/live", false},
+		{"rtmp://ingest.example.com/live/key", "ingest.example.com/live", false},
+	}
+
+	for _, test := range tests {
+		if got := urlUnder(test.url, test.prefix); got != test.want {
+			t.Errorf("urlUnder(%q, %q) = %t, want %t", test.url, test.prefix, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package room
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "zoomgaming/proto"
+	utils "zoomgaming/utils"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Text chat between everyone in the room, players and spectators alike, across the ChatRoom data channel
+
+The server stamps each message with its sender and the time before passing it on to everyone, sender included.
+Recent messages are kept and replayed to each connection when its ChatRoom channel opens.
+
+*/
+
+const (
+	maxChatLength  = 500 // characters in one message
+	chatBurst      = 5   // messages a connection may send within chatWindow
+	chatWindow     = 5 * time.Second
+	maxChatHistory = 50 // messages replayed to new joiners
+)
+
+// Replay recent history to a connection, then pass on what it says until its channel closes
+func (r *room) openChat(conn rtc.WebRTC, msgs <-chan proto.Message) {
+
+	r.mu.Lock()
+	if _, prs := r.members[conn]; prs {
+		r.chatters[conn] = struct{}{}
+		for _, msg := range r.chatLog {
+			err := conn.Send(msg)
+			utils.WarnOnError(r.connLog(conn), err, "Error replaying chat history")
+		}
+	}
+	r.mu.Unlock()
+
+	var sent []time.Time // when the connection's recent messages were sent, oldest first
+	for msg := range msgs {
+
+		text := strings.TrimSpace(msg.(*pb.ChatMessage).GetText())
+		if text == "" {
+			continue
+		}
+
+		if utf8.RuneCountInString(text) > maxChatLength {
+			err := conn.Signal(rtc.ErrorEvent(rtc.NewSignalingError(pb.SignalingError_CODE_MESSAGE_TOO_LONG,
+				"chat messages are at most %d characters", maxChatLength)))
+			utils.WarnOnError(r.connLog(conn), err, "Error rejecting chat message")
+			continue
+		}
+
+		now := time.Now()
+		for len(sent) > 0 && now.Sub(sent[0]) >= chatWindow {
+			sent = sent[1:]
+		}
+		if len(sent) >= chatBurst {
+			err := conn.Signal(rtc.ErrorEvent(rtc.NewSignalingError(pb.SignalingError_CODE_RATE_LIMITED,
+				"at most %d chat messages every %s", chatBurst, chatWindow)))
+			utils.WarnOnError(r.connLog(conn), err, "Error rejecting chat message")
+			continue
+		}
+		sent = append(sent, now)
+
+		r.mu.Lock()
+		if player, prs := r.members[conn]; prs {
+			r.sendChat(&pb.ChatMessage{
+				SenderId:   player.ID,
+				SenderName: player.Name,
+				SentAt:     timestamppb.New(now),
+				Text:       text,
+			})
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Send a chat message to everyone with an open ChatRoom channel and remember it for later joiners
+func (r *room) sendChat(msg *pb.ChatMessage) {
+
+	r.chatLog = append(r.chatLog, msg)
+	if len(r.chatLog) > maxChatHistory {
+		r.chatLog = r.chatLog[len(r.chatLog)-maxChatHistory:]
+	}
+
+	for conn := range r.chatters {
+		err := conn.Send(msg)
+		utils.WarnOnError(r.connLog(conn), err, "Error sending chat message")
+	}
+}
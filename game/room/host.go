@@ -0,0 +1,194 @@
+package room
+
+import (
+	"fmt"
+
+	game "zoomgaming/game"
+	pb "zoomgaming/proto"
+	utils "zoomgaming/utils"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+The host manages the room with room controls sent across the signaling connection,
+and everyone in the room hears about the outcome on their RoomState data channel
+
+The host is the client that created the room through the REST API, or else the first client to join.
+When the host leaves, the role passes to the player in the lowest seat, or else to any spectator.
+
+*/
+
+func (r *room) Kick(id string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.removeClient(id, pb.SignalingError_CODE_KICKED, "kicked by the host")
+}
+
+func (r *room) Ban(id string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id == r.host {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_TARGET, "the host cannot ban themselves")
+	}
+
+	r.banned[id] = struct{}{}
+	r.stopViewers(id)
+
+	if r.connByID(id) == nil {
+		return nil // ban clients that have not joined yet, or already left
+	}
+	return r.removeClient(id, pb.SignalingError_CODE_BANNED, "banned by the host")
+}
+
+func (r *room) Lock(locked bool) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.locked = locked
+}
+
+func (r *room) TransferHost(id string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.connByID(id) == nil {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "no client %s", id)
+	}
+
+	r.setHost(id)
+	return nil
+}
+
+// Apply room controls sent by a connection until it closes
+//
+// Controls from anyone but the host are refused
+func (r *room) handleControls(conn rtc.WebRTC) {
+	for ctrl := range conn.Controls() {
+
+		r.mu.Lock()
+		isHost := r.members[conn] != nil && r.members[conn].ID == r.host
+		r.mu.Unlock()
+
+		var err error
+		if !isHost {
+			err = rtc.NewSignalingError(pb.SignalingError_CODE_NOT_HOST, "only the host can manage the room")
+		} else {
+			err = r.applyControl(ctrl)
+		}
+
+		if err != nil {
+			err = conn.Signal(rtc.ErrorEvent(err))
+			utils.WarnOnError(r.connLog(conn), err, "Error refusing room control")
+		}
+	}
+}
+
+func (r *room) applyControl(ctrl *pb.RoomControl) error {
+	switch c := ctrl.GetControl().(type) {
+	case *pb.RoomControl_Kick:
+		return r.Kick(c.Kick)
+	case *pb.RoomControl_Ban:
+		return r.Ban(c.Ban)
+	case *pb.RoomControl_Lock:
+		r.Lock(c.Lock)
+		return nil
+	case *pb.RoomControl_TransferHost:
+		return r.TransferHost(c.TransferHost)
+	case *pb.RoomControl_Promote_:
+		return r.Promote(c.Promote.GetClientId(), game.PlayerIndex(c.Promote.GetSeat()))
+	case *pb.RoomControl_Demote:
+		return r.Demote(c.Demote)
+	case *pb.RoomControl_Swap_:
+		return r.Swap(game.PlayerIndex(c.Swap.GetSeatA()), game.PlayerIndex(c.Swap.GetSeatB()))
+	case *pb.RoomControl_SwitchGame:
+		return r.SwitchGame(c.SwitchGame)
+	case *pb.RoomControl_Countdown:
+		r.StartCountdown(c.Countdown)
+		return nil
+	case *pb.RoomControl_Voice:
+		r.SetVoice(c.Voice)
+		return nil
+	case *pb.RoomControl_Record:
+		if c.Record {
+			_, err := r.StartRecording()
+			return err
+		}
+		_, err := r.StopRecording()
+		return err
+	case *pb.RoomControl_Broadcast:
+		if c.Broadcast == "" {
+			return r.StopBroadcast()
+		}
+		return r.StartBroadcast(c.Broadcast)
+	default:
+		return fmt.Errorf("unexpected room control: %T", c)
+	}
+}
+
+// Refuse clients that the host has banned, or any new client while the room is locked
+func (r *room) checkClient(id string) error {
+
+	if _, prs := r.banned[id]; prs {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_BANNED, "banned from the room")
+	}
+	if r.connByID(id) != nil {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_ALREADY_JOINED, "already in the room")
+	}
+	if r.locked && id != r.host {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_ROOM_LOCKED, "the room is locked")
+	}
+
+	return nil
+}
+
+// Tell a client why it is being removed and disconnect it
+func (r *room) removeClient(id string, code pb.SignalingError_Code, reason string) error {
+
+	if id == r.host {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_TARGET, "the host cannot remove themselves")
+	}
+
+	conn := r.connByID(id)
+	if conn == nil {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "no client %s", id)
+	}
+
+	err := conn.Signal(rtc.ErrorEvent(rtc.NewSignalingError(code, reason)))
+	utils.WarnOnError(r.connLog(conn), err, "Error notifying removed client")
+
+	r.leave(conn)
+	conn.Close()
+
+	return nil
+}
+
+// The player in the lowest seat, else any spectator, else nobody
+func (r *room) nextHost() string {
+	for idx := game.Player1; int(idx) <= r.typ.Seats(); idx++ {
+		if conn, prs := r.players[idx]; prs {
+			return r.members[conn].ID
+		}
+	}
+	for spectator := range r.spectators {
+		return r.members[spectator].ID
+	}
+	return ""
+}
+
+func (r *room) setHost(id string) {
+
+	r.host = id
+
+	r.publish(&pb.RoomEvent{
+		Event: &pb.RoomEvent_HostChange{
+			HostChange: &pb.HostChange{HostId: id},
+		},
+	})
+}
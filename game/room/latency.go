@@ -0,0 +1,123 @@
+package room
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "zoomgaming/proto"
+	utils "zoomgaming/utils"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Latency of each connection, probed across its Latency data channel
+
+The room pings every connection and answers its pings. Every few seconds, it tells the connection
+how long it takes for a frame of the game to reach its screen: the time to capture and encode the
+frame, half a round trip, and the time the client reports holding the frame before showing it.
+
+*/
+
+const (
+	pingInterval          = 2 * time.Second
+	maxPingAge            = 10 * time.Second // pings without a pong by then are taken as lost
+	latencyReportInterval = 5 * time.Second
+)
+
+// Latency of a connection, as last reported to it
+type Latency struct {
+	RTT          time.Duration // round trip across the data channel
+	ICERTT       time.Duration // round trip measured by ICE, 0 if unknown
+	CaptureDelay time.Duration // from capturing a frame to sending it
+	PlayoutDelay time.Duration // as reported by the client
+	Estimate     time.Duration // from capturing a frame to showing it, glass to glass
+}
+
+// Ping a connection and answer its pings until its channel closes
+func (r *room) probeLatency(conn rtc.WebRTC, msgs <-chan proto.Message) {
+
+	pings := make(map[uint32](time.Time)) // when each unanswered ping was sent, by id
+	var lastPing uint32
+	var rtt, playoutDelay time.Duration
+
+	pingTicker := time.NewTicker(pingInterval)
+	defer pingTicker.Stop()
+	reportTicker := time.NewTicker(latencyReportInterval)
+	defer reportTicker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return
+			}
+			switch p := msg.(*pb.LatencyProbe).GetProbe().(type) {
+			case *pb.LatencyProbe_Ping:
+				err := conn.Send(&pb.LatencyProbe{
+					Probe: &pb.LatencyProbe_Pong{Pong: &pb.Pong{Id: p.Ping.GetId()}},
+				})
+				utils.WarnOnError(r.connLog(conn), err, "Error answering ping")
+			case *pb.LatencyProbe_Pong:
+				if sent, prs := pings[p.Pong.GetId()]; prs {
+					rtt = time.Since(sent)
+					delete(pings, p.Pong.GetId())
+				}
+				playoutDelay = time.Duration(p.Pong.GetPlayoutDelayMs()) * time.Millisecond
+			}
+
+		case now := <-pingTicker.C:
+			for id, sent := range pings {
+				if now.Sub(sent) > maxPingAge {
+					delete(pings, id)
+				}
+			}
+
+			lastPing++
+			pings[lastPing] = now
+			err := conn.Send(&pb.LatencyProbe{
+				Probe: &pb.LatencyProbe_Ping{Ping: &pb.Ping{Id: lastPing}},
+			})
+			utils.WarnOnError(r.connLog(conn), err, "Error sending ping")
+
+		case <-reportTicker.C:
+			r.reportLatency(conn, rtt, playoutDelay)
+		}
+	}
+}
+
+func (r *room) reportLatency(conn rtc.WebRTC, rtt time.Duration, playoutDelay time.Duration) {
+
+	latency := Latency{
+		RTT:          rtt,
+		ICERTT:       conn.Stats().ICERTT,
+		CaptureDelay: r.videoStream.CaptureDelay(),
+		PlayoutDelay: playoutDelay,
+	}
+
+	network := latency.RTT
+	if network == 0 {
+		network = latency.ICERTT // no pong yet
+	}
+	latency.Estimate = latency.CaptureDelay + network/2 + latency.PlayoutDelay
+
+	r.mu.Lock()
+	if player, prs := r.members[conn]; prs {
+		player.Latency = latency
+	}
+	r.mu.Unlock()
+
+	err := conn.Send(&pb.LatencyProbe{
+		Probe: &pb.LatencyProbe_Report{
+			Report: &pb.LatencyReport{
+				RttMs:          uint32(latency.RTT / time.Millisecond),
+				IceRttMs:       uint32(latency.ICERTT / time.Millisecond),
+				CaptureDelayMs: uint32(latency.CaptureDelay / time.Millisecond),
+				PlayoutDelayMs: uint32(latency.PlayoutDelay / time.Millisecond),
+				EstimateMs:     uint32(latency.Estimate / time.Millisecond),
+			},
+		},
+	})
+	utils.WarnOnError(r.connLog(conn), err, "Error sending latency report")
+}
@@ -0,0 +1,86 @@
+package room
+
+import (
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	game "zoomgaming/game"
+	pb "zoomgaming/proto"
+	rtc "zoomgaming/webrtc"
+)
+
+// A client in the room, seated as a player or watching as a spectator
+type Player struct {
+	ID        string // client id, from the room token
+	Name      string // display name, from the room token
+	Role      pb.Role
+	Seat      game.PlayerIndex // PlayerUndefined for spectators
+	JoinedAt  time.Time
+	Stats     rtc.ConnectionStats
+	Latency   Latency
+	Quality   pb.Quality // as last graded from Stats
+	Rendition string     // of the video the connection receives, e.g. 640x360@800k, if the room has renditions
+}
+
+func (p *Player) proto() *pb.Player {
+	return &pb.Player{
+		ClientId: p.ID,
+		Name:     p.Name,
+		Role:     p.Role,
+		Seat:     uint32(p.Seat),
+		JoinedAt: timestamppb.New(p.JoinedAt),
+	}
+}
+
+// A snapshot of everyone in the room, ordered by seat and then by join time
+func (r *room) Players() []Player {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	players := make([]Player, 0, len(r.members))
+	for conn, p := range r.members {
+		player := *p
+		player.Stats = conn.Stats()
+		players = append(players, player)
+	}
+	sortPlayers(players)
+
+	return players
+}
+
+func sortPlayers(players []Player) {
+	sort.Slice(players, func(i, j int) bool {
+		a, b := players[i], players[j]
+		if a.Seat != b.Seat {
+			// spectators, with seat 0, go last
+			return a.Seat != game.PlayerUndefined && (b.Seat == game.PlayerUndefined || a.Seat < b.Seat)
+		}
+		return a.JoinedAt.Before(b.JoinedAt)
+	})
+}
+
+// Tell everyone in the room who is in it
+func (r *room) broadcastMembers() {
+	r.publish(r.membersEvent())
+}
+
+func (r *room) membersEvent() *pb.RoomEvent {
+
+	players := make([]Player, 0, len(r.members))
+	for _, p := range r.members {
+		players = append(players, *p)
+	}
+	sortPlayers(players)
+
+	members := &pb.RoomMembers{HostId: r.host}
+	for i := range players {
+		members.Players = append(members.Players, players[i].proto())
+	}
+
+	return &pb.RoomEvent{
+		Event: &pb.RoomEvent_Members{Members: members},
+	}
+}
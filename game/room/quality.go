@@ -0,0 +1,89 @@
+package room
+
+import (
+	"time"
+
+	pb "zoomgaming/proto"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Connection quality of everyone in the room
+
+Every few seconds the room grades each connection from the packet loss and round trip time its
+browser reports, and tells everyone, so that clients can show a quality indicator next to each player.
+
+*/
+
+const qualityInterval = 5 * time.Second
+
+// Thresholds past which a connection is graded fair, or poor
+const (
+	fairLoss = 0.01
+	poorLoss = 0.05
+	fairRTT  = 100 * time.Millisecond
+	poorRTT  = 250 * time.Millisecond
+)
+
+// Grade everyone's connection, until the room shuts down
+func (r *room) monitorQuality() {
+
+	ticker := time.NewTicker(qualityInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.quit:
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		quality := &pb.ConnectionQuality{}
+		for conn, player := range r.members {
+			peer := peerQuality(conn.Stats())
+			peer.ClientId = player.ID
+			player.Quality = peer.Quality
+			quality.Peers = append(quality.Peers, peer)
+		}
+		if len(quality.Peers) > 0 {
+			r.publish(&pb.RoomEvent{
+				Event: &pb.RoomEvent_ConnectionQuality{ConnectionQuality: quality},
+			})
+		}
+		r.mu.Unlock()
+	}
+}
+
+func peerQuality(stats rtc.ConnectionStats) *pb.PeerQuality {
+
+	// Receiver reports only arrive once media flows, fall back on ICE until then
+	rtt := stats.RTT
+	if rtt == 0 {
+		rtt = stats.ICERTT
+	}
+
+	return &pb.PeerQuality{
+		Quality:       grade(stats.FractionLost, rtt, stats.State),
+		RttMs:         uint32(rtt / time.Millisecond),
+		Loss:          float32(stats.FractionLost),
+		JitterMs:      uint32(stats.Jitter / time.Millisecond),
+		CandidateType: stats.CandidateType,
+	}
+}
+
+func grade(loss float64, rtt time.Duration, state string) pb.Quality {
+	switch {
+	case state == "" || state == "new" || state == "checking":
+		return pb.Quality_QUALITY_UNSPECIFIED
+	case state != "connected" && state != "completed":
+		return pb.Quality_QUALITY_POOR // disconnected or failed
+	case loss > poorLoss || rtt > poorRTT:
+		return pb.Quality_QUALITY_POOR
+	case loss > fairLoss || rtt > fairRTT:
+		return pb.Quality_QUALITY_FAIR
+	default:
+		return pb.Quality_QUALITY_GOOD
+	}
+}
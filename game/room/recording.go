@@ -0,0 +1,170 @@
+package room
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "zoomgaming/proto"
+	"zoomgaming/recording"
+	utils "zoomgaming/utils"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+The host may record the room's audio and video to disk, if the server was given a directory for recordings
+
+Everyone in the room is told when recording starts and stops. Recording stops when the room shuts down.
+
+Anyone in the room may also save the last seconds of the room as a clip, if the room keeps a replay buffer.
+
+*/
+
+const clipInterval = 5 * time.Second // between clips of the same room
+
+// Start recording, or carry on with the recording that is already running
+func (r *room) StartRecording() (recording.Recording, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cfg.RecordingDir == "" {
+		return recording.Recording{}, rtc.NewSignalingError(pb.SignalingError_CODE_RECORDING_UNAVAILABLE, "the server does not record rooms")
+	}
+
+	r.recMu.Lock()
+	defer r.recMu.Unlock()
+
+	if r.recorder != nil {
+		return r.recorder.Recording(), nil
+	}
+
+	recorder, err := recording.NewRecorder(r.cfg.RecordingDir, r.cfg.ID, r.log)
+	if err != nil {
+		r.log.Error("Error starting recording", zap.Error(err))
+		return recording.Recording{}, rtc.NewSignalingError(pb.SignalingError_CODE_RECORDING_UNAVAILABLE, "could not start recording")
+	}
+	r.recorder = recorder
+
+	rec := recorder.Recording()
+	r.log.Info("Started recording", zap.String("recording", rec.ID))
+	r.publish(recordingEvent(rec))
+
+	return rec, nil
+}
+
+func (r *room) StopRecording() (recording.Recording, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.stopRecorder()
+	if !ok {
+		return recording.Recording{}, rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "the room is not recording")
+	}
+
+	r.publish(recordingEvent(rec))
+	return rec, nil
+}
+
+// Stop the recorder if there is one, must hold r.mu
+func (r *room) stopRecorder() (recording.Recording, bool) {
+
+	r.recMu.Lock()
+	recorder := r.recorder
+	r.recorder = nil
+	r.recMu.Unlock()
+
+	if recorder == nil {
+		return recording.Recording{}, false
+	}
+
+	rec, err := recorder.Stop()
+	utils.WarnOnError(r.log, err, "Error finishing recording", zap.String("recording", rec.ID))
+	r.log.Info("Stopped recording", zap.String("recording", rec.ID), zap.Duration("length", rec.StoppedAt.Sub(rec.StartedAt)))
+
+	return rec, true
+}
+
+// Pass a packet of the game's streams on to the recorder, if the room is recording
+func (r *room) record(pckt []byte, write func(recording.Recorder, []byte)) {
+
+	r.recMu.Lock()
+	defer r.recMu.Unlock()
+
+	if r.recorder != nil {
+		write(r.recorder, pckt)
+	}
+}
+
+// Must hold r.mu
+func (r *room) recordingEvents() []*pb.RoomEvent {
+
+	r.recMu.Lock()
+	defer r.recMu.Unlock()
+
+	if r.recorder == nil {
+		return nil
+	}
+	return []*pb.RoomEvent{recordingEvent(r.recorder.Recording())}
+}
+
+func recordingEvent(rec recording.Recording) *pb.RoomEvent {
+
+	state := &pb.Recording{Active: rec.Active()}
+	if rec.Active() {
+		state.StartedAt = timestamppb.New(rec.StartedAt)
+	}
+
+	return &pb.RoomEvent{
+		Event: &pb.RoomEvent_Recording{Recording: state},
+	}
+}
+
+// Save the replay buffer as a clip, at most once every clipInterval for the whole room
+func (r *room) SaveClip() (recording.Recording, error) {
+
+	if r.replay == nil {
+		return recording.Recording{}, rtc.NewSignalingError(pb.SignalingError_CODE_RECORDING_UNAVAILABLE, "the server does not keep instant replays")
+	}
+
+	r.mu.Lock()
+	if time.Since(r.lastClip) < clipInterval {
+		r.mu.Unlock()
+		return recording.Recording{}, rtc.NewSignalingError(pb.SignalingError_CODE_RATE_LIMITED, "a clip was saved less than %s ago", clipInterval)
+	}
+	r.lastClip = time.Now()
+	r.mu.Unlock()
+
+	clip, err := r.replay.Save(r.cfg.RecordingDir, r.cfg.ID, r.log)
+	if err != nil {
+		r.log.Error("Error saving clip", zap.Error(err))
+		return recording.Recording{}, rtc.NewSignalingError(pb.SignalingError_CODE_RECORDING_UNAVAILABLE, "could not save the clip")
+	}
+
+	r.log.Info("Saved clip", zap.String("recording", clip.ID), zap.Duration("length", clip.StoppedAt.Sub(clip.StartedAt)))
+	return clip, nil
+}
+
+// Save a clip for a connection that asked for one on its RoomState channel
+func (r *room) sendClip(conn rtc.WebRTC) {
+
+	clip, err := r.SaveClip()
+	if err != nil {
+		err = conn.Signal(rtc.ErrorEvent(err))
+		utils.WarnOnError(r.connLog(conn), err, "Error refusing clip request")
+		return
+	}
+
+	err = conn.Send(&pb.RoomEvent{
+		Event: &pb.RoomEvent_Clip{Clip: &pb.Clip{
+			Id:       clip.ID,
+			VideoUrl: recording.FileURL(clip.Room, clip.Video),
+			AudioUrl: recording.FileURL(clip.Room, clip.Audio),
+			LengthMs: uint32(clip.StoppedAt.Sub(clip.StartedAt) / time.Millisecond),
+		}},
+	})
+	utils.WarnOnError(r.connLog(conn), err, "Error sending clip")
+}
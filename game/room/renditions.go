@@ -0,0 +1,216 @@
+package room
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3"
+	"go.uber.org/zap"
+
+	game "zoomgaming/game"
+	"zoomgaming/recording"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Rooms with renditions send each connection the video it has the bandwidth for
+
+Layer 0 is the full video stream, and the renditions follow from the largest to the smallest.
+Every few seconds each connection's layer is picked from the bandwidth its browser estimates,
+and the connection's own video track switches to that layer on the layer's next keyframe,
+carrying on the sequence numbers and timestamps of the previous layer so the browser sees one stream.
+
+Connections whose browser has not estimated its bandwidth yet get the full stream. So do WHEP viewers,
+who share the room's video track.
+
+*/
+
+const (
+	layerInterval = 2 * time.Second // how often each connection's layer is picked
+	headroom      = 1.25            // estimated bandwidth per bit of a layer to stay on it
+	upgradeMargin = 1.5             // estimated bandwidth per bit of a higher layer to switch up to it
+)
+
+// Forwards one layer at a time to a connection's own video track
+type layerSwitch struct {
+	mu         *sync.Mutex
+	track      *webrtc.TrackLocalStaticRTP
+	frameTicks uint32 // between the last frame of a layer and the first of the next, in rtp ticks
+	current    int    // layer being forwarded, -1 until the first keyframe
+	target     int    // layer to switch to on its next keyframe
+	lastSeq    uint16
+	lastTS     uint32
+	seqOffset  uint16
+	tsOffset   uint32
+}
+
+func newLayerSwitch(framerate int) (*layerSwitch, error) {
+
+	track, err := webrtc.NewTrackLocalStaticRTP(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeH264}, "video", "GameStream")
+	if err != nil {
+		return nil, err
+	}
+
+	return &layerSwitch{
+		mu:         &sync.Mutex{},
+		track:      track,
+		frameTicks: uint32(90000 / framerate),
+		current:    -1,
+	}, nil
+}
+
+// Forward a packet of a layer, if it is the current layer or the target layer starts a keyframe
+func (s *layerSwitch) write(layer int, pckt []byte) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if layer != s.current && layer != s.target {
+		return
+	}
+
+	pkt := &rtp.Packet{}
+	if err := pkt.Unmarshal(pckt); err != nil {
+		return
+	}
+
+	if layer != s.current {
+		if !recording.IsKeyFrame(pkt.Payload) {
+			return
+		}
+		if s.current >= 0 {
+			s.seqOffset = s.lastSeq + 1 - pkt.SequenceNumber
+			s.tsOffset = s.lastTS + s.frameTicks - pkt.Timestamp
+		}
+		s.current = layer
+	}
+
+	pkt.SequenceNumber += s.seqOffset
+	pkt.Timestamp += s.tsOffset
+	s.lastSeq = pkt.SequenceNumber
+	s.lastTS = pkt.Timestamp
+
+	s.track.WriteRTP(pkt)
+}
+
+// The layer being forwarded, and the layer to switch to
+func (s *layerSwitch) layers() (int, int) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current, s.target
+}
+
+func (s *layerSwitch) setTarget(layer int) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.target = layer
+}
+
+// Pass a packet of a layer on to every connection's switch
+func (r *room) forwardLayer(layer int, pckt []byte) {
+
+	r.layerMu.Lock()
+	defer r.layerMu.Unlock()
+
+	for _, s := range r.layerSwitches {
+		s.write(layer, pckt)
+	}
+}
+
+// The size and bitrate of a layer, layer 0 is the room's encode profile
+func (r *room) layer(idx int) game.Rendition {
+	if idx <= 0 {
+		return game.Rendition{Width: r.cfg.Profile.Width, Height: r.cfg.Profile.Height, Bitrate: r.cfg.Profile.Bitrate}
+	}
+	return r.cfg.Renditions[idx-1]
+}
+
+// Pick each connection's layer, until the room shuts down
+func (r *room) monitorLayers() {
+
+	ticker := time.NewTicker(layerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.quit:
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		r.layerMu.Lock()
+		for conn, s := range r.layerSwitches {
+
+			current, target := s.layers()
+			next := r.pickLayer(conn.Stats().Bandwidth, current)
+			if next != target {
+				r.connLog(conn).Debug("Switching video rendition", zap.Stringer("rendition", r.layer(next)), zap.Uint64("bandwidth", conn.Stats().Bandwidth))
+				s.setTarget(next)
+			}
+
+			if player, prs := r.members[conn]; prs && current >= 0 {
+				player.Rendition = r.layer(current).String()
+			}
+		}
+		r.layerMu.Unlock()
+		r.mu.Unlock()
+	}
+}
+
+// The largest layer that fits a bandwidth estimate, in bits per second, else the smallest layer
+//
+// Moving up from the current layer takes more spare bandwidth than staying on it, so that connections do not flap
+func (r *room) pickLayer(bandwidth uint64, current int) int {
+
+	if bandwidth == 0 {
+		return 0
+	}
+
+	for idx := 0; idx <= len(r.cfg.Renditions); idx++ {
+		margin := headroom
+		if current >= 0 && idx < current {
+			margin = upgradeMargin
+		}
+		if float64(r.layer(idx).Bitrate)*1000*margin <= float64(bandwidth) {
+			return idx
+		}
+	}
+	return len(r.cfg.Renditions)
+}
+
+// Give a new connection its own video track, if the room has renditions, must hold r.mu
+func (r *room) newVideoTrack() (*webrtc.TrackLocalStaticRTP, *layerSwitch, error) {
+
+	if len(r.renditions) == 0 {
+		return r.videoTrack, nil, nil
+	}
+
+	s, err := newLayerSwitch(r.cfg.Profile.Framerate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.track, s, nil
+}
+
+func (r *room) addLayerSwitch(conn rtc.WebRTC, s *layerSwitch) {
+
+	r.layerMu.Lock()
+	defer r.layerMu.Unlock()
+
+	r.layerSwitches[conn] = s
+}
+
+func (r *room) removeLayerSwitch(conn rtc.WebRTC) {
+
+	r.layerMu.Lock()
+	defer r.layerMu.Unlock()
+
+	delete(r.layerSwitches, conn)
+}
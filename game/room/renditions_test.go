@@ -0,0 +1,38 @@
+package room
+
+import (
+	"testing"
+
+	game "zoomgaming/game"
+)
+
+func TestPickLayer(t *testing.T) {
+
+	r := &room{cfg: Config{
+		Profile:    game.EncodeProfile{Width: 1280, Height: 720, Framerate: 60, Bitrate: 2400},
+		Renditions: []game.Rendition{{Width: 854, Height: 480, Bitrate: 1200}, {Width: 640, Height: 360, Bitrate: 600}},
+	}}
+
+	tests := []struct {
+		name      string
+		bandwidth uint64 // kbit/s
+		current   int
+		want      int
+	}{
+		{"no estimate yet", 0, 1, 0},
+		{"room for the full stream", 4000, -1, 0},
+		{"headroom for the full stream", 3000, -1, 0},
+		{"no headroom for the full stream", 2900, -1, 1},
+		{"stays on the full stream", 3000, 0, 0},
+		{"not enough spare to move up", 3200, 1, 1},
+		{"enough spare to move up", 3600, 1, 0},
+		{"moves down", 1000, 0, 2},
+		{"smallest layer when nothing fits", 100, 0, 2},
+	}
+
+	for _, test := range tests {
+		if got := r.pickLayer(test.bandwidth*1000, test.current); got != test.want {
+			t.Errorf("%s: layer %d at %dk from layer %d, want %d", test.name, got, test.bandwidth, test.current, test.want)
+		}
+	}
+}
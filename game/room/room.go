@@ -3,12 +3,22 @@ package room
 import (
 	"errors"
 	"fmt"
-	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pion/webrtc/v3"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	game "zoomgaming/game"
+	"zoomgaming/hls"
+	"zoomgaming/inputlog"
+	"zoomgaming/logging"
+	"zoomgaming/metrics"
+	pb "zoomgaming/proto"
+	"zoomgaming/recording"
+	"zoomgaming/rtmp"
 	utils "zoomgaming/utils"
 	rtc "zoomgaming/webrtc"
 	ws "zoomgaming/websocket"
@@ -17,80 +27,294 @@ import (
 /**
 
 A room is associated with 1 game at a time
-Up to 4 players in a room
-
-// At some point, the players should have some identifying information beyond just an RTCPeerConnection
+The game decides how many players can be seated in a room, the room config decides how many spectators
 
 */
 
 type Room interface {
-	// SwitchGame(string) error
-	NewPlayer(ws.WebSocket) error
+	SwitchGame(string) error // start another game on the room's display, everyone stays connected
+	NewPlayer(ws.WebSocket, JoinRequest) error
+	Promote(string, game.PlayerIndex) error // seat a spectator, PlayerUndefined picks any free seat
+	Demote(string) error                    // move a player to the spectators
+	Swap(game.PlayerIndex, game.PlayerIndex) error
+	Kick(string) error         // remove a client from the room
+	Ban(string) error          // remove a client and refuse its joins for the rest of the room's lifetime
+	Lock(bool)                 // refuse or accept new joins, the host may always rejoin
+	TransferHost(string) error // hand the host role to another client in the room
+	StartCountdown(uint32)     // count down from some seconds on every client, 0 cancels
+	SetVoice(bool)             // turn voice chat on or off for everyone
+	Players() []Player
+	StartRecording() (recording.Recording, error)
+	StopRecording() (recording.Recording, error)
+	StartBroadcast(string) error // push the room's audio and video to an rtmp url
+	StopBroadcast() error
+	WatchWHEP(string, string) (rtc.WHEP, error) // answer the offer of a client who watches without joining
+	StopWHEP(string, string) error              // stop a viewer, for the client who started watching with it or the host
+	SaveClip() (recording.Recording, error)
+	Stats() Stats
+	Snapshot() ([]byte, time.Time, error) // the latest frame of the room as a JPEG, and when it was grabbed
 	Done() <-chan struct{}
 	Close()
 }
 
 type room struct {
+	audioBytes  uint64 // written to the audio track since the last health report, accessed atomically
+	videoBytes  uint64 // written to the video track since the last health report, accessed atomically
 	game        game.Game
 	typ         game.GameType
+	index       int                         // the room's slot on the server, which picks its X display
+	log         *zap.Logger                 // with the room's id
 	audioTrack  *webrtc.TrackLocalStaticRTP // the game's audio track, shared between all players
 	videoTrack  *webrtc.TrackLocalStaticRTP // the game's video track, shared between all players
 	audioStream game.Stream
 	videoStream game.Stream
-	// playerTracks []*webrtc.TrackLocalStaticRTP
 
-	mu      *sync.Mutex // protects players
-	players map[game.PlayerIndex](rtc.WebRTC)
-	spectators []rtc.WebRTC
-	done    chan struct{}
+	mixer           game.Mixer                  // nil unless the room mixes a commentary track
+	commentaryTrack *webrtc.TrackLocalStaticRTP // the game's audio mixed with voice chat, shared between all connections
+
+	seatInputs map[game.PlayerIndex](chan game.Input) // attached to the game once per seat, fed by whoever holds the seat
+
+	mu         *sync.Mutex // protects everything below
+	players    map[game.PlayerIndex](rtc.WebRTC)
+	spectators map[rtc.WebRTC]struct{}
+	members    map[rtc.WebRTC]*Player  // every player and spectator
+	listeners  map[rtc.WebRTC]struct{} // connections with an open RoomState data channel
+	chatters   map[rtc.WebRTC]struct{} // connections with an open ChatRoom data channel
+	chatLog    []*pb.ChatMessage       // most recent chat messages, oldest first
+	host       string                  // client id of the host
+	locked     bool
+	banned     map[string]struct{}
+	countdown  chan struct{}    // closed to cancel the running countdown, nil if there is none
+	health     *pb.StreamHealth // last stream health report
+	closed     bool
+	cfg        Config
+	done       chan struct{}
+	quit       chan struct{} // closed once the room shuts down
+
+	// voice chat, see voice.go
+	voice       bool                                           // whether players can talk to each other
+	voiceTracks map[rtc.WebRTC]([]*webrtc.TrackLocalStaticRTP) // each listener's voice tracks, by seat
+	talking     map[rtc.WebRTC]struct{}                        // players holding push-to-talk
+	mutes       map[rtc.WebRTC](map[string]struct{})           // client ids that each listener does not want to hear
+	voiceRoutes atomic.Value                                   // map[rtc.WebRTC](voiceRoute) of whoever is heard, read without holding mu
+
+	// recording, see recording.go
+	recMu    *sync.Mutex        // protects recorder and broadcast, which the streams write to without holding mu
+	recorder recording.Recorder // nil unless the room is recording
+	replay   recording.Replay   // nil unless the room keeps a replay buffer
+	lastClip time.Time          // protected by mu
+	inputLog inputlog.Writer    // nil unless the room logs its input
+
+	broadcast rtmp.Broadcast // nil unless the room is pushed to rtmp, see broadcast.go
+
+	// renditions, see renditions.go
+	renditions    []game.Stream                 // smaller encodes of the display, one per layer after the full stream
+	layerMu       *sync.Mutex                   // protects layerSwitches, which the streams forward to without holding mu
+	layerSwitches map[rtc.WebRTC](*layerSwitch) // the video of each connection, if the room has renditions
+
+	viewers map[rtc.WHEP]string // client ids of everyone watching over WHEP, protected by mu, see whep.go
+
+	egress hls.Egress // nil unless the room is streamed over HLS
+
+	// lobby previews, see snapshot.go
+	snapMu     *sync.Mutex // protects the latest snapshot, held while grabbing a new one
+	snapshot   []byte
+	snapshotAt time.Time
+}
+
+// Room settings that do not depend on the game being played
+type Config struct {
+	ID               string             // room id, for logging
+	MaxSpectators    int                // connections beyond the game's seats that may watch the room
+	EmptyTimeout     time.Duration      // close the room if nobody has joined it by then
+	Host             string             // client id of the host, the first client to join if empty
+	Voice            bool               // let players talk to each other, the host may change it later
+	Commentary       *game.MixerGains   // mix the game's audio and voice chat into a commentary track, nil to leave it out
+	RecordingDir     string             // where the host may record the room, empty to not allow recording
+	ReplayLength     time.Duration      // how much of the room to keep for instant replays, 0 for none, needs a RecordingDir
+	InputLogDir      string             // where to log the input of the room's games, empty for no log
+	SnapshotInterval time.Duration      // how long a snapshot of the room is served before grabbing a new one
+	HLSDir           string             // where to write the HLS segments of rooms that stream over HLS
+	HLS              bool               // stream the room over HLS into HLSDir, as asked for when the room was created
+	BroadcastURLs    []string           // prefixes of the rtmp urls the host may push the room to
+	Renditions       []game.Rendition   // smaller encodes of the video, from the largest, for connections without the bandwidth for the full stream
+	Profile          game.EncodeProfile // how the room's display is captured and encoded, the game's profile unless the room was created with its own
+}
+
+// What a new connection asks to do in the room
+type JoinRequest struct {
+	ClientID string // identifies the client across connections, a new id is made up if empty
+	Name     string // display name of the client
+	Role     pb.Role
+	Seat     game.PlayerIndex // preferred seat when joining as a player, PlayerUndefined for any seat
 }
 
-func NewRoom(typ game.GameType, roomIndex int) (res Room, err error) {
+func NewRoom(typ game.GameType, roomIndex int, cfg Config) (res Room, err error) {
 
+	var audioStream game.Stream
+	var videoStream game.Stream
+	var mixer game.Mixer
+	var g game.Game
+	var inputLog inputlog.Writer
+	var egress hls.Egress
+	var renditions []game.Stream
+
+	// Stop whatever was started before the failure, the rest of the server carries on
 	defer func() {
 		if r := recover(); r != nil {
+			for _, s := range append([]game.Stream{videoStream, audioStream}, renditions...) {
+				if s != nil {
+					s.Stop()
+				}
+			}
+			if mixer != nil {
+				mixer.Stop()
+			}
+			if g != nil {
+				g.Stop()
+			}
+			if inputLog != nil {
+				inputLog.Close()
+			}
+			if egress != nil {
+				egress.Stop()
+			}
 			err = errors.New(fmt.Sprintf("%s", r))
 		}
 	}()
 
-	var audioStream game.Stream
-	var videoStream game.Stream
+	log := logging.L().With(logging.Room(cfg.ID))
 
 	switch typ {
 	case game.TestGame:
-		videoStream, err = game.NewStream(game.TestH264, roomIndex)
-		utils.FailOnError(err, "Error starting video stream: %s")
-		audioStream, err = game.NewStream(game.TestOpus, roomIndex)
-		utils.FailOnError(err, "Error starting audio stream: %s")
+		videoStream, err = game.NewStream(game.TestH264, cfg.Profile, roomIndex, log)
+		utils.PanicOnError(err, "Error starting video stream: %s")
+		audioStream, err = game.NewStream(game.TestOpus, cfg.Profile, roomIndex, log)
+		utils.PanicOnError(err, "Error starting audio stream: %s")
 	default:
-		videoStream, err = game.NewStream(game.VideoSH, roomIndex)
-		utils.FailOnError(err, "Error starting video stream: %s")
-		audioStream, err = game.NewStream(game.AudioSH, roomIndex)
-		utils.FailOnError(err, "Error starting audio stream: %s")
+		videoStream, err = game.NewStream(game.VideoSH, cfg.Profile, roomIndex, log)
+		utils.PanicOnError(err, "Error starting video stream: %s")
+		audioStream, err = game.NewStream(game.AudioSH, cfg.Profile, roomIndex, log)
+		utils.PanicOnError(err, "Error starting audio stream: %s")
+	}
+
+	// Renditions are only worth encoding if they are smaller than the room's own video
+	var fitting []game.Rendition
+	for _, rend := range cfg.Renditions {
+		if !rend.Fits(cfg.Profile) {
+			log.Warn("Skipping rendition that is not smaller than the video stream", zap.Stringer("rendition", rend), zap.Stringer("profile", cfg.Profile))
+			continue
+		}
+		fitting = append(fitting, rend)
+	}
+	cfg.Renditions = fitting
+
+	for i, rend := range cfg.Renditions {
+		s, err := game.NewRendition(rend, cfg.Profile, i+1, typ == game.TestGame, roomIndex, log)
+		utils.PanicOnError(err, "Error starting %s rendition: %s", rend)
+		renditions = append(renditions, s)
 	}
 
-	g, err := game.NewGame(typ, roomIndex)
-	utils.FailOnError(err, "Error creating game: ")
+	g, err = game.NewGame(typ, roomIndex, log)
+	utils.PanicOnError(err, "Error creating game: %s")
 
 	// Create a video track
 	videoTrack, err := webrtc.NewTrackLocalStaticRTP(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeH264}, "video", "GameStream")
-	utils.FailOnError(err, "Error creating video track: ")
+	utils.PanicOnError(err, "Error creating video track: %s")
 
 	// Create an audio track
 	audioTrack, err := webrtc.NewTrackLocalStaticRTP(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus}, "audio", "GameStream")
-	utils.FailOnError(err, "Error creating audio track: ")
+	utils.PanicOnError(err, "Error creating audio track: %s")
+
+	var commentaryTrack *webrtc.TrackLocalStaticRTP
+	if cfg.Commentary != nil {
+		mixer, err = game.NewMixer(roomIndex, typ.Seats(), *cfg.Commentary, log)
+		utils.PanicOnError(err, "Error starting commentary mixer: %s")
+
+		commentaryTrack, err = webrtc.NewTrackLocalStaticRTP(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus}, "commentary", "Commentary")
+		utils.PanicOnError(err, "Error creating commentary track: %s")
+	}
+
+	var replay recording.Replay
+	if cfg.ReplayLength > 0 && cfg.RecordingDir != "" {
+		replay = recording.NewReplay(cfg.ReplayLength)
+	}
+
+	// The room carries on without an input log if it cannot write one
+	if cfg.InputLogDir != "" {
+		var logErr error
+		inputLog, logErr = inputlog.NewWriter(cfg.InputLogDir, cfg.ID, typ.String())
+		utils.WarnOnError(log, logErr, "Error starting input log")
+	}
+
+	// The room carries on without HLS if ffmpeg will not start, spectators may still join over WebRTC
+	if cfg.HLS && cfg.HLSDir != "" {
+		var hlsErr error
+		egress, hlsErr = hls.NewEgress(cfg.HLSDir, cfg.ID, roomIndex, log)
+		utils.WarnOnError(log, hlsErr, "Error starting HLS egress")
+	}
 
 	r := &room{
-		game:        g,
-		typ:         typ,
-		audioTrack:  audioTrack,
-		videoTrack:  videoTrack,
-		audioStream: audioStream,
-		videoStream: videoStream,
-		mu:          &sync.Mutex{},
-		players:     make(map[game.PlayerIndex](rtc.WebRTC)),
-		spectators:  make([]rtc.WebRTC, 0),
-		done:        make(chan struct{}),
+		game:            g,
+		typ:             typ,
+		index:           roomIndex,
+		log:             log,
+		audioTrack:      audioTrack,
+		videoTrack:      videoTrack,
+		audioStream:     audioStream,
+		videoStream:     videoStream,
+		mixer:           mixer,
+		commentaryTrack: commentaryTrack,
+		seatInputs:      make(map[game.PlayerIndex](chan game.Input)),
+		mu:              &sync.Mutex{},
+		players:         make(map[game.PlayerIndex](rtc.WebRTC)),
+		spectators:      make(map[rtc.WebRTC]struct{}),
+		members:         make(map[rtc.WebRTC]*Player),
+		listeners:       make(map[rtc.WebRTC]struct{}),
+		chatters:        make(map[rtc.WebRTC]struct{}),
+		voice:           cfg.Voice,
+		recMu:           &sync.Mutex{},
+		replay:          replay,
+		inputLog:        inputLog,
+		egress:          egress,
+		viewers:         make(map[rtc.WHEP]string),
+		renditions:      renditions,
+		layerMu:         &sync.Mutex{},
+		layerSwitches:   make(map[rtc.WebRTC](*layerSwitch)),
+		snapMu:          &sync.Mutex{},
+		voiceTracks:     make(map[rtc.WebRTC]([]*webrtc.TrackLocalStaticRTP)),
+		talking:         make(map[rtc.WebRTC]struct{}),
+		mutes:           make(map[rtc.WebRTC](map[string]struct{})),
+		host:            cfg.Host,
+		banned:          make(map[string]struct{}),
+		cfg:             cfg,
+		done:            make(chan struct{}),
+		quit:            make(chan struct{}),
+	}
+
+	for idx := game.Player1; int(idx) <= typ.Seats(); idx++ {
+		r.seatInputs[idx] = make(chan game.Input, 1024)
+		err = g.AttachInputStream(r.seatInputs[idx], idx)
+		utils.PanicOnError(err, "Error attaching input for %s: %s", idx)
+	}
+
+	if cfg.EmptyTimeout > 0 {
+		time.AfterFunc(cfg.EmptyTimeout, r.closeIfEmpty)
+	}
+
+	go r.monitorStreams()
+	go r.monitorQuality()
+	go r.watchEncoder(videoStream, "video")
+	go r.watchEncoder(audioStream, "audio")
+	if mixer != nil {
+		go r.watchEncoder(mixer, "commentary")
+	}
+	for i, s := range renditions {
+		go r.watchEncoder(s, cfg.Renditions[i].String())
+	}
+	go r.watchGame(g, typ)
+	if len(renditions) > 0 {
+		go r.monitorLayers()
 	}
 
 	go func() {
@@ -98,7 +322,19 @@ func NewRoom(typ game.GameType, roomIndex int) (res Room, err error) {
 		case ch := <-r.audioStream.Updates():
 			go func() {
 				for pckt := range ch {
+					atomic.AddUint64(&r.audioBytes, uint64(len(pckt)))
 					r.audioTrack.Write(pckt)
+					r.record(pckt, recording.Recorder.WriteAudio)
+					r.push(pckt, rtmp.Broadcast.WriteAudio)
+					if r.replay != nil {
+						r.replay.WriteAudio(pckt)
+					}
+					if r.egress != nil {
+						r.egress.WriteAudio(pckt)
+					}
+					if r.mixer != nil {
+						r.mixer.Write(game.PlayerUndefined, pckt)
+					}
 				}
 			}()
 		}
@@ -109,99 +345,194 @@ func NewRoom(typ game.GameType, roomIndex int) (res Room, err error) {
 		case ch := <-r.videoStream.Updates():
 			go func() {
 				for pckt := range ch {
+					atomic.AddUint64(&r.videoBytes, uint64(len(pckt)))
 					r.videoTrack.Write(pckt)
+					r.forwardLayer(0, pckt)
+					r.record(pckt, recording.Recorder.WriteVideo)
+					r.push(pckt, rtmp.Broadcast.WriteVideo)
+					if r.replay != nil {
+						r.replay.WriteVideo(pckt)
+					}
+					if r.egress != nil {
+						r.egress.WriteVideo(pckt)
+					}
 				}
 			}()
 		}
 	}()
 
+	for i, s := range renditions {
+		go func(layer int, s game.Stream) {
+			for pckt := range <-s.Updates() {
+				r.forwardLayer(layer, pckt)
+			}
+		}(i+1, s)
+	}
+
+	if r.mixer != nil {
+		go func() {
+			select {
+			case ch := <-r.mixer.Updates():
+				go func() {
+					for pckt := range ch {
+						r.commentaryTrack.Write(pckt)
+					}
+				}()
+			}
+		}()
+	}
+
 	res = r
 	return
 }
 
-/**
+// Start another game on the room's display
+//
+// Everyone stays connected, players in seats that the new game does not have become spectators.
+// The encoders keep running, so the room keeps its encode profile rather than taking the new game's
 func (r *room) SwitchGame(game_id string) error {
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	typ := game.GameTypeOf(game_id)
+	switch {
+	case typ == game.GameUndefined:
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_GAME, "unknown game: %s", game_id)
+	case typ == r.typ:
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_GAME, "already playing %s", typ)
+	case typ == game.TestGame || r.typ == game.TestGame:
+		// the test game streams test sources rather than the display, so the streams would have to change too
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_GAME, "cannot switch to or from %s", game.TestGame)
+	}
 
-	if typ == game.GameUndefined || r.typ == typ {
-		return errors.New("Unable to switch game...")
-	} else {
+	g, err := game.NewGame(typ, r.index, r.log)
+	if err != nil {
+		return err
+	}
 
-		r.game.Stop()
+	for idx, ch := range r.seatInputs {
+		r.releaseKeys(idx)
+		close(ch)
+	}
+	r.game.Stop()
 
-		g, err := game.NewGame(typ)
-		if err != nil {
-			return err
-		}
+	r.game = g
+	r.typ = typ
+	if r.inputLog != nil {
+		r.inputLog.Switch(typ.String())
+	}
+	go r.watchGame(g, typ)
+	r.seatInputs = make(map[game.PlayerIndex](chan game.Input))
+	for idx := game.Player1; int(idx) <= typ.Seats(); idx++ {
+		r.seatInputs[idx] = make(chan game.Input, 1024)
+		err = g.AttachInputStream(r.seatInputs[idx], idx)
+		utils.WarnOnError(r.log, err, "Error attaching input", logging.Game(typ.String()), logging.Seat(int(idx)))
+	}
 
-		for _, conn := range r.players {
-			conn.Close()
+	for idx, conn := range r.players {
+		if int(idx) > typ.Seats() {
+			delete(r.players, idx)
+			r.spectators[conn] = struct{}{}
+			r.sendAssignment(conn, game.PlayerUndefined)
 		}
-		r.players = make(map[game.PlayerIndex](rtc.WebRTC))
+	}
 
-		r.game = g
-		r.typ = typ
+	r.log.Info("Switched game", logging.Game(typ.String()))
 
-		return nil
-	}
+	r.publish(&pb.RoomEvent{
+		Event: &pb.RoomEvent_GameSwitch{GameSwitch: &pb.GameSwitch{GameId: typ.String()}},
+	})
+	r.broadcastMembers()
+
+	return nil
 }
-*/
 
-func (r *room) NewPlayer(ws ws.WebSocket) error {
+// The room's logger, with a connection's id
+func (r *room) connLog(conn rtc.WebRTC) *zap.Logger {
+	return r.log.With(logging.Peer(conn.ID()))
+}
+
+func (r *room) NewPlayer(ws ws.WebSocket, req JoinRequest) error {
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	var idx game.PlayerIndex
-	mappings := []game.PlayerIndex{game.Player1, game.Player2, game.Player3, game.Player4}
-	for _, player := range mappings {
-		_, prs := r.players[player]
-		if !prs {
-			idx = player
-			break
+	if r.closed {
+		return errors.New("room closed")
+	}
+
+	if req.ClientID != "" {
+		if err := r.checkClient(req.ClientID); err != nil {
+			return err
 		}
 	}
 
-	rtc, err := rtc.NewWebRTC(ws, r.videoTrack, r.audioTrack)
+	idx, err := r.assignSeat(req)
 	if err != nil {
 		return err
 	}
 
-	if idx != game.PlayerUndefined {
-	// CHANGE THIS: Use the first data channel (GameInput) as input for game
-	dcs := rtc.DataChannels()
-	go func() {
-		defer r.removePlayer(idx) // remove player if the rtc connection shuts down
-		for ch := range dcs {
-			r.game.AttachInputStream(ch, idx)
-		}
-	}()
+	voiceTracks, err := newVoiceTracks(r.typ.Seats())
+	if err != nil {
+		return err
+	}
 
-	r.players[idx] = rtc
+	videoTrack, layers, err := r.newVideoTrack()
+	if err != nil {
+		return err
+	}
 
-	log.Println("number of players in the room after adding: ", len(r.players))
+	rtc, err := rtc.NewWebRTC(ws, videoTrack, r.audioTrack, voiceTracks, r.commentaryTrack, metrics.TimeToAnswer.WithLabelValues(r.typ.String()), r.log)
+	if err != nil {
+		return err
+	}
+	r.voiceTracks[rtc] = voiceTracks
+	if layers != nil {
+		r.addLayerSwitch(rtc, layers)
+	}
 
+	id := req.ClientID
+	if id == "" {
+		id = rtc.ID()
+	}
+	role := pb.Role_ROLE_PLAYER
+	if idx == game.PlayerUndefined {
+		role = pb.Role_ROLE_SPECTATOR
+	}
+	r.members[rtc] = &Player{
+		ID:       id,
+		Name:     req.Name,
+		Role:     role,
+		Seat:     idx,
+		JoinedAt: time.Now(),
+	}
+	r.updateVoiceRoutes()
+
+	go r.watchDataChannels(rtc)
+	go r.handleControls(rtc)
+	go r.handleVoiceControls(rtc)
+	go r.watchVoice(rtc)
+
+	if idx != game.PlayerUndefined {
+		r.players[idx] = rtc
 	} else {
-		dcs := rtc.DataChannels()
-		go func() {
-			for _ = range dcs {}
-		}()
-		r.spectators = append(r.spectators, rtc)
+		r.spectators[rtc] = struct{}{}
 	}
-	/**
-	tracks := rtc.Broadcast()
-	go func() {
-		for track := range tracks {
-			r.mu.Lock()
-			r.playerTracks.append(track)
-			r.mu.Unlock()
-		}
-	}()
-	*/
+	r.connLog(rtc).Info("Joined", logging.Client(id), logging.Seat(int(idx)), zap.Int("players", len(r.players)))
+
+	err = rtc.Signal(&pb.SignalingEvent{
+		Event: &pb.SignalingEvent_JoinResponse{
+			JoinResponse: &pb.JoinResponse{Role: role, Seat: uint32(idx), ClientId: id, HostId: r.host, Seats: uint32(len(voiceTracks)), Commentary: r.mixer != nil, Video: r.cfg.Profile.Proto()},
+		},
+	})
+	utils.WarnOnError(r.connLog(rtc), err, "Error sending join response")
+
+	if r.host == "" {
+		r.setHost(id)
+	}
+	r.broadcastMembers()
+
 	return nil
 }
 
@@ -215,23 +546,151 @@ func (r *room) Close() {
 	}
 }
 
-func (r *room) removePlayer(idx game.PlayerIndex) {
+// Serve a connection's data channels as they open
+//
+// Input is forwarded to whichever seat the connection holds when the message arrives
+func (r *room) watchDataChannels(conn rtc.WebRTC) {
+	defer r.removeConn(conn) // remove the player or spectator if the rtc connection shuts down
+	for dc := range conn.DataChannels() {
+		switch dc.Label {
+		case rtc.GameInput:
+			go r.forwardInput(conn, dc.Messages)
+		case rtc.RoomState:
+			go r.openState(conn, dc.Messages)
+		case rtc.ChatRoom:
+			go r.openChat(conn, dc.Messages)
+		case rtc.Latency:
+			go r.probeLatency(conn, dc.Messages)
+		}
+	}
+}
+
+// Relay a connection's input to the game, or reject it if the connection is spectating
+func (r *room) forwardInput(conn rtc.WebRTC, ch <-chan proto.Message) {
+	for msg := range ch {
+		received := time.Now()
+
+		r.mu.Lock()
+		idx := r.seatOf(conn)
+		if idx != game.PlayerUndefined && !r.closed {
+			if !r.inject(idx, game.Input{Message: msg, ReceivedAt: received}) {
+				r.connLog(conn).Warn("Input dropped, the game is not keeping up", logging.Seat(int(idx)))
+			}
+		}
+		r.mu.Unlock()
+
+		// Spectators have no seat to control, tell them each time they send input anyways
+		if idx == game.PlayerUndefined {
+			err := conn.Signal(rtc.ErrorEvent(rtc.NewSignalingError(pb.SignalingError_CODE_INPUT_REJECTED,
+				"spectators cannot send %T", msg)))
+			utils.WarnOnError(r.connLog(conn), err, "Error rejecting spectator input")
+		}
+	}
+}
+
+// Pass input on to the game, and to the input log, false if the game is not keeping up
+// Only what players sent is logged, not the key releases the server makes up
+//
+// Must hold r.mu
+func (r *room) inject(idx game.PlayerIndex, input game.Input) bool {
+
+	select {
+	case r.seatInputs[idx] <- input:
+	default:
+		return false
+	}
+
+	if evt, ok := input.Message.(*pb.InputEvent); ok && r.inputLog != nil && !input.Synthetic {
+		r.inputLog.Write(uint32(idx), evt)
+	}
+	return true
+}
+
+// The room shuts down once a connection leaves and no players remain
+func (r *room) removeConn(conn rtc.WebRTC) {
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	_, prs := r.players[idx]
-	if prs {
+	r.leave(conn)
+}
+
+func (r *room) leave(conn rtc.WebRTC) {
+
+	player, prs := r.members[conn]
+	if !prs {
+		return
+	}
+
+	if idx := r.seatOf(conn); idx != game.PlayerUndefined {
+		r.releaseKeys(idx)
 		delete(r.players, idx)
 	}
+	delete(r.spectators, conn)
+	delete(r.members, conn)
+	delete(r.listeners, conn)
+	delete(r.chatters, conn)
+	delete(r.voiceTracks, conn)
+	delete(r.talking, conn)
+	delete(r.mutes, conn)
+	r.updateVoiceRoutes()
+	r.removeLayerSwitch(conn)
 
 	if len(r.players) == 0 {
-		for _, spectator := range r.spectators {
-			spectator.Close()
-		}
-		r.videoStream.Stop()
-		r.audioStream.Stop()
-		r.game.Stop()
-		r.done <- struct{}{}
+		r.shutdown()
+		return
+	}
+
+	if player.ID == r.host {
+		r.setHost(r.nextHost())
+	}
+	r.broadcastMembers()
+}
+
+func (r *room) closeIfEmpty() {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.members) == 0 {
+		r.shutdown()
+	}
+}
+
+// Stop the game and its streams and disconnect everyone that is left
+func (r *room) shutdown() {
+
+	if r.closed {
+		return
+	}
+	r.closed = true
+	close(r.quit)
+
+	for spectator := range r.spectators {
+		spectator.Close()
+	}
+	for viewer := range r.viewers {
+		viewer.Close()
+	}
+	for _, ch := range r.seatInputs {
+		close(ch)
+	}
+	r.stopRecorder()
+	r.stopBroadcast()
+	if r.inputLog != nil {
+		utils.WarnOnError(r.log, r.inputLog.Close(), "Error closing input log")
+	}
+	r.videoStream.Stop()
+	r.audioStream.Stop()
+	for _, s := range r.renditions {
+		s.Stop()
+	}
+	if r.egress != nil {
+		r.egress.Stop()
+	}
+	if r.mixer != nil {
+		r.mixer.Stop()
 	}
+	r.game.Stop()
+	r.done <- struct{}{}
 }
@@ -0,0 +1,265 @@
+package room
+
+import (
+	"time"
+
+	game "zoomgaming/game"
+	pb "zoomgaming/proto"
+	utils "zoomgaming/utils"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Seat assignment for the connections in a room
+
+A seat's input stream is attached to the game once, when the room is created.
+Moving a connection between seats only changes where its input is forwarded,
+so neither peer has to renegotiate its WebRTC connection.
+
+*/
+
+// Seat a spectator, identified by client id
+func (r *room) Promote(id string, idx game.PlayerIndex) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conn := r.spectatorByID(id)
+	if conn == nil {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "no spectator %s", id)
+	}
+
+	if idx == game.PlayerUndefined {
+		idx = r.freeSeat()
+		if idx == game.PlayerUndefined {
+			return rtc.NewSignalingError(pb.SignalingError_CODE_SEATS_FULL, "all %d seats are taken", r.typ.Seats())
+		}
+	} else if err := r.checkSeat(idx); err != nil {
+		return err
+	} else if _, prs := r.players[idx]; prs {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_SEAT_TAKEN, "%s is taken", idx)
+	}
+
+	delete(r.spectators, conn)
+	r.players[idx] = conn
+	r.sendAssignment(conn, idx)
+	r.broadcastMembers()
+
+	return nil
+}
+
+// Move a player to the spectators, freeing their seat
+//
+// Like leaving, demoting the last player shuts the room down. Viewers watching over WHEP do not take
+// the spectator slots of the room's own connections, so they never keep a player from being demoted
+func (r *room) Demote(id string) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx := r.seatByID(id)
+	if idx == game.PlayerUndefined {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "no player %s", id)
+	}
+
+	if len(r.spectators) >= r.cfg.MaxSpectators {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_SPECTATORS_FULL,
+			"all %d spectator slots are taken", r.cfg.MaxSpectators)
+	}
+
+	conn := r.players[idx]
+	r.releaseKeys(idx)
+	delete(r.players, idx)
+	r.spectators[conn] = struct{}{}
+
+	if len(r.players) == 0 {
+		r.shutdown()
+		return nil
+	}
+
+	r.sendAssignment(conn, game.PlayerUndefined)
+	r.broadcastMembers()
+
+	return nil
+}
+
+// Exchange the players in two seats, either seat may be empty
+func (r *room) Swap(a game.PlayerIndex, b game.PlayerIndex) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkSeat(a); err != nil {
+		return err
+	}
+	if err := r.checkSeat(b); err != nil {
+		return err
+	}
+
+	connA, prsA := r.players[a]
+	connB, prsB := r.players[b]
+	if !prsA && !prsB {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "%s and %s are both empty", a, b)
+	}
+
+	r.releaseKeys(a)
+	r.releaseKeys(b)
+	delete(r.players, a)
+	delete(r.players, b)
+
+	if prsA {
+		r.players[b] = connA
+		r.sendAssignment(connA, b)
+	}
+	if prsB {
+		r.players[a] = connB
+		r.sendAssignment(connB, a)
+	}
+	r.broadcastMembers()
+
+	return nil
+}
+
+// Pick the seat for a new connection, PlayerUndefined means the connection will spectate
+//
+// A preferred seat that is already taken falls back to any free seat
+func (r *room) assignSeat(req JoinRequest) (game.PlayerIndex, error) {
+
+	seats := r.typ.Seats()
+
+	if req.Seat != game.PlayerUndefined {
+		if err := r.checkSeat(req.Seat); err != nil {
+			return game.PlayerUndefined, err
+		}
+	}
+
+	idx := game.PlayerUndefined
+	if req.Role != pb.Role_ROLE_SPECTATOR {
+		if _, prs := r.players[req.Seat]; req.Seat != game.PlayerUndefined && !prs {
+			idx = req.Seat
+		} else {
+			idx = r.freeSeat()
+		}
+	}
+
+	switch {
+	case idx != game.PlayerUndefined:
+		return idx, nil
+	case req.Role == pb.Role_ROLE_PLAYER:
+		return idx, rtc.NewSignalingError(pb.SignalingError_CODE_SEATS_FULL,
+			"all %d seats are taken", seats)
+	case r.spectatorCount() < r.cfg.MaxSpectators:
+		return idx, nil
+	case req.Role == pb.Role_ROLE_SPECTATOR:
+		return idx, rtc.NewSignalingError(pb.SignalingError_CODE_SPECTATORS_FULL,
+			"all %d spectator slots are taken", r.cfg.MaxSpectators)
+	default:
+		return idx, rtc.NewSignalingError(pb.SignalingError_CODE_ROOM_FULL,
+			"room is full: %d of %d seats, %d of %d spectators", len(r.players), seats, r.spectatorCount(), r.cfg.MaxSpectators)
+	}
+}
+
+// The lowest free seat, or PlayerUndefined if every seat is taken
+func (r *room) freeSeat() game.PlayerIndex {
+	for idx := game.Player1; int(idx) <= r.typ.Seats(); idx++ {
+		if _, prs := r.players[idx]; !prs {
+			return idx
+		}
+	}
+	return game.PlayerUndefined
+}
+
+func (r *room) checkSeat(idx game.PlayerIndex) error {
+	if idx < game.Player1 || int(idx) > r.typ.Seats() {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_INVALID_SEAT,
+			"%s has %d seats, requested seat %d", r.typ, r.typ.Seats(), idx)
+	}
+	return nil
+}
+
+// The seat held by a connection, or PlayerUndefined for spectators
+func (r *room) seatOf(conn rtc.WebRTC) game.PlayerIndex {
+	for idx, player := range r.players {
+		if player == conn {
+			return idx
+		}
+	}
+	return game.PlayerUndefined
+}
+
+func (r *room) seatByID(id string) game.PlayerIndex {
+	for idx, player := range r.players {
+		if r.members[player].ID == id {
+			return idx
+		}
+	}
+	return game.PlayerUndefined
+}
+
+func (r *room) spectatorByID(id string) rtc.WebRTC {
+	for spectator := range r.spectators {
+		if r.members[spectator].ID == id {
+			return spectator
+		}
+	}
+	return nil
+}
+
+// The connection of a player or spectator, nil if the client is not in the room
+func (r *room) connByID(id string) rtc.WebRTC {
+	for conn, player := range r.members {
+		if player.ID == id {
+			return conn
+		}
+	}
+	return nil
+}
+
+// Let go of every key the seat has, so that nothing stays pressed once a seat changes hands
+//
+// Keys the game does not map for the seat have no keycode, so they are left alone
+func (r *room) releaseKeys(idx game.PlayerIndex) {
+
+	if r.closed {
+		return
+	}
+
+	for key := range game.GameMappings[r.typ][idx] {
+		evt := &pb.InputEvent{
+			Event: &pb.InputEvent_KeyPressEvent{
+				KeyPressEvent: &pb.KeyPressEvent{
+					Direction: pb.KeyPressEvent_DIRECTION_UP,
+					Key:       key,
+				},
+			},
+		}
+		r.inject(idx, game.Input{Message: evt, ReceivedAt: time.Now(), Synthetic: true})
+	}
+}
+
+// Record a connection's new seat and tell it, PlayerUndefined for spectating
+//
+// Connections whose RoomState channel is not open yet learn their seat from the snapshot sent when it opens
+func (r *room) sendAssignment(conn rtc.WebRTC, idx game.PlayerIndex) {
+
+	role := pb.Role_ROLE_PLAYER
+	if idx == game.PlayerUndefined {
+		role = pb.Role_ROLE_SPECTATOR
+	}
+
+	r.members[conn].Role = role
+	r.members[conn].Seat = idx
+	r.updateVoiceRoutes()
+
+	if _, prs := r.listeners[conn]; !prs {
+		return
+	}
+
+	err := conn.Send(&pb.RoomEvent{
+		Event: &pb.RoomEvent_SeatAssignment{
+			SeatAssignment: &pb.SeatAssignment{Role: role, Seat: uint32(idx)},
+		},
+	})
+	utils.WarnOnError(r.connLog(conn), err, "Error sending seat assignment")
+}
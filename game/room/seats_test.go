@@ -0,0 +1,264 @@
+package room
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	game "zoomgaming/game"
+	pb "zoomgaming/proto"
+	rtc "zoomgaming/webrtc"
+)
+
+// A connection that is never sent anything, the room has no listeners in these tests
+type fakeConn struct {
+	rtc.WebRTC
+	id     string
+	closed bool
+}
+
+func (c *fakeConn) ID() string { return c.id }
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+type fakeViewer struct {
+	rtc.WHEP
+	id     string
+	closed bool
+}
+
+func (v *fakeViewer) ID() string { return v.id }
+
+func (v *fakeViewer) Close() error {
+	v.closed = true
+	return nil
+}
+
+type fakeStream struct {
+	game.Stream
+}
+
+func (s *fakeStream) Stop() {}
+
+type fakeGame struct {
+	game.Game
+}
+
+func (g *fakeGame) Stop() {}
+
+// A room of some game, without streams or connections, whose inputs are kept for the test to read
+func newTestRoom(typ game.GameType, maxSpectators int) *room {
+
+	r := &room{
+		game:          &fakeGame{},
+		typ:           typ,
+		log:           zap.NewNop(),
+		audioStream:   &fakeStream{},
+		videoStream:   &fakeStream{},
+		seatInputs:    make(map[game.PlayerIndex](chan game.Input)),
+		mu:            &sync.Mutex{},
+		players:       make(map[game.PlayerIndex](rtc.WebRTC)),
+		spectators:    make(map[rtc.WebRTC]struct{}),
+		members:       make(map[rtc.WebRTC]*Player),
+		listeners:     make(map[rtc.WebRTC]struct{}),
+		chatters:      make(map[rtc.WebRTC]struct{}),
+		recMu:         &sync.Mutex{},
+		layerMu:       &sync.Mutex{},
+		layerSwitches: make(map[rtc.WebRTC](*layerSwitch)),
+		viewers:       make(map[rtc.WHEP]string),
+		cfg:           Config{MaxSpectators: maxSpectators},
+		done:          make(chan struct{}, 1),
+		quit:          make(chan struct{}),
+	}
+	for idx := game.Player1; int(idx) <= typ.Seats(); idx++ {
+		r.seatInputs[idx] = make(chan game.Input, 1024)
+	}
+	return r
+}
+
+// Add a connection to a seat, or to the spectators for PlayerUndefined
+func (r *room) addTestConn(id string, idx game.PlayerIndex) *fakeConn {
+
+	conn := &fakeConn{id: id}
+	role := pb.Role_ROLE_PLAYER
+	if idx == game.PlayerUndefined {
+		role = pb.Role_ROLE_SPECTATOR
+		r.spectators[conn] = struct{}{}
+	} else {
+		r.players[idx] = conn
+	}
+	r.members[conn] = &Player{ID: id, Role: role, Seat: idx}
+	return conn
+}
+
+// The key presses injected into a seat so far
+func (r *room) testInputs(idx game.PlayerIndex) []*pb.KeyPressEvent {
+
+	var keys []*pb.KeyPressEvent
+	for {
+		select {
+		case input := <-r.seatInputs[idx]:
+			keys = append(keys, input.Message.(*pb.InputEvent).GetKeyPressEvent())
+		default:
+			return keys
+		}
+	}
+}
+
+func checkCode(t *testing.T, name string, err error, code pb.SignalingError_Code) {
+	t.Helper()
+
+	var serr *rtc.SignalingError
+	switch {
+	case code == pb.SignalingError_CODE_UNSPECIFIED && err != nil:
+		t.Errorf("%s: %s", name, err)
+	case code == pb.SignalingError_CODE_UNSPECIFIED:
+	case !errors.As(err, &serr):
+		t.Errorf("%s: %v, want %s", name, err, code)
+	case serr.Code != code:
+		t.Errorf("%s: %s, want %s", name, serr.Code, code)
+	}
+}
+
+func checkSeat(t *testing.T, r *room, conn rtc.WebRTC, idx game.PlayerIndex) {
+	t.Helper()
+
+	if seat := r.seatOf(conn); seat != idx {
+		t.Errorf("%s is in %s, want %s", conn.ID(), seat, idx)
+	}
+	if _, spectating := r.spectators[conn]; spectating != (idx == game.PlayerUndefined) {
+		t.Errorf("%s spectating: %t, in %s", conn.ID(), spectating, idx)
+	}
+	if player := r.members[conn]; player.Seat != idx {
+		t.Errorf("%s is a member in %s, want %s", conn.ID(), player.Seat, idx)
+	}
+}
+
+func TestPromote(t *testing.T) {
+
+	r := newTestRoom(game.SpaceTime, 4)
+	r.addTestConn("p1", game.Player1)
+	a := r.addTestConn("a", game.PlayerUndefined)
+	b := r.addTestConn("b", game.PlayerUndefined)
+
+	checkCode(t, "no spectator", r.Promote("p1", game.PlayerUndefined), pb.SignalingError_CODE_NOT_FOUND)
+	checkCode(t, "taken seat", r.Promote("a", game.Player1), pb.SignalingError_CODE_SEAT_TAKEN)
+	checkCode(t, "invalid seat", r.Promote("a", game.Player5), pb.SignalingError_CODE_INVALID_SEAT)
+
+	checkCode(t, "any seat", r.Promote("a", game.PlayerUndefined), pb.SignalingError_CODE_UNSPECIFIED)
+	checkSeat(t, r, a, game.Player2)
+	checkCode(t, "some seat", r.Promote("b", game.Player4), pb.SignalingError_CODE_UNSPECIFIED)
+	checkSeat(t, r, b, game.Player4)
+
+	r.addTestConn("p3", game.Player3)
+	r.addTestConn("c", game.PlayerUndefined)
+	checkCode(t, "seats full", r.Promote("c", game.PlayerUndefined), pb.SignalingError_CODE_SEATS_FULL)
+}
+
+func TestDemote(t *testing.T) {
+
+	r := newTestRoom(game.SpaceTime, 1)
+	p1 := r.addTestConn("p1", game.Player1)
+	p2 := r.addTestConn("p2", game.Player2)
+	r.addTestConn("p3", game.Player3)
+	r.viewers[&fakeViewer{}] = "viewer"
+
+	checkCode(t, "no player", r.Demote("nobody"), pb.SignalingError_CODE_NOT_FOUND)
+
+	// The viewer does not take the only spectator slot
+	checkCode(t, "demote", r.Demote("p2"), pb.SignalingError_CODE_UNSPECIFIED)
+	checkSeat(t, r, p2, game.PlayerUndefined)
+
+	// Only the keys of the seat are released
+	released := r.testInputs(game.Player2)
+	if len(released) != len(game.GameMappings[game.SpaceTime][game.Player2]) {
+		t.Errorf("released %d keys, want the %d the seat has", len(released), len(game.GameMappings[game.SpaceTime][game.Player2]))
+	}
+	for _, key := range released {
+		if _, prs := game.GameMappings[game.SpaceTime][game.Player2][key.GetKey()]; !prs || key.GetDirection() != pb.KeyPressEvent_DIRECTION_UP {
+			t.Errorf("released %s %s, which the seat does not have", key.GetKey(), key.GetDirection())
+		}
+	}
+
+	checkCode(t, "spectators full", r.Demote("p1"), pb.SignalingError_CODE_SPECTATORS_FULL)
+	checkSeat(t, r, p1, game.Player1)
+
+	// Demoting the last player shuts the room down, like leaving
+	r = newTestRoom(game.SpaceTime, 4)
+	last := r.addTestConn("last", game.Player1)
+	spectator := r.addTestConn("spectator", game.PlayerUndefined)
+
+	checkCode(t, "last player", r.Demote("last"), pb.SignalingError_CODE_UNSPECIFIED)
+	if !r.closed {
+		t.Error("room is open without players")
+	}
+	select {
+	case <-r.Done():
+	default:
+		t.Error("room is not done without players")
+	}
+	if !last.closed || !spectator.closed {
+		t.Error("room shut down without disconnecting its spectators")
+	}
+}
+
+func TestSwap(t *testing.T) {
+
+	r := newTestRoom(game.SpaceTime, 4)
+	p1 := r.addTestConn("p1", game.Player1)
+	p2 := r.addTestConn("p2", game.Player2)
+
+	checkCode(t, "invalid seat", r.Swap(game.Player1, game.Player5), pb.SignalingError_CODE_INVALID_SEAT)
+	checkCode(t, "both empty", r.Swap(game.Player3, game.Player4), pb.SignalingError_CODE_NOT_FOUND)
+
+	checkCode(t, "swap", r.Swap(game.Player1, game.Player2), pb.SignalingError_CODE_UNSPECIFIED)
+	checkSeat(t, r, p1, game.Player2)
+	checkSeat(t, r, p2, game.Player1)
+
+	checkCode(t, "to an empty seat", r.Swap(game.Player4, game.Player1), pb.SignalingError_CODE_UNSPECIFIED)
+	checkSeat(t, r, p2, game.Player4)
+	if _, prs := r.players[game.Player1]; prs {
+		t.Error("seat 1 is taken after swapping its player to an empty seat")
+	}
+
+	// Both seats let go of their keys
+	for _, idx := range []game.PlayerIndex{game.Player1, game.Player2, game.Player4} {
+		if keys := r.testInputs(idx); len(keys) == 0 {
+			t.Errorf("no keys released for %s", idx)
+		}
+	}
+}
+
+type fakeInputLog struct {
+	events []*pb.InputEvent
+}
+
+func (l *fakeInputLog) Switch(string) {}
+
+func (l *fakeInputLog) Write(seat uint32, evt *pb.InputEvent) {
+	l.events = append(l.events, evt)
+}
+
+func (l *fakeInputLog) Close() error { return nil }
+
+func TestInputLogLeavesOutKeyReleases(t *testing.T) {
+
+	r := newTestRoom(game.SpaceTime, 4)
+	inputLog := &fakeInputLog{}
+	r.inputLog = inputLog
+	r.addTestConn("p1", game.Player1)
+	r.addTestConn("p2", game.Player2)
+
+	sent := &pb.InputEvent{Sequence: 1}
+	r.inject(game.Player1, game.Input{Message: sent})
+	checkCode(t, "swap", r.Swap(game.Player1, game.Player2), pb.SignalingError_CODE_UNSPECIFIED)
+
+	if len(inputLog.events) != 1 || inputLog.events[0] != sent {
+		t.Errorf("logged %d events, want only the one a player sent", len(inputLog.events))
+	}
+}
@@ -0,0 +1,61 @@
+package room
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	game "zoomgaming/game"
+)
+
+/**
+
+Snapshots of the room's display, for previews in the lobby
+
+A snapshot is grabbed when asked for, and served to everyone who asks until it is older than the
+refresh interval, so that however often the lobby polls, each room grabs at most one frame per interval.
+
+The test game has no display to grab, its rooms encode the grey picture of their video instead.
+
+*/
+
+func (r *room) Snapshot() ([]byte, time.Time, error) {
+
+	r.mu.Lock()
+	closed := r.closed
+	typ := r.typ
+	r.mu.Unlock()
+
+	if closed {
+		return nil, time.Time{}, errors.New("room closed")
+	}
+
+	// Whoever arrives while a snapshot is being grabbed waits for it rather than grabbing another
+	r.snapMu.Lock()
+	defer r.snapMu.Unlock()
+
+	if r.snapshot != nil && time.Since(r.snapshotAt) < r.cfg.SnapshotInterval {
+		return r.snapshot, r.snapshotAt, nil
+	}
+
+	at := time.Now()
+	var jpeg []byte
+	var err error
+	if typ == game.TestGame {
+		jpeg, err = game.TestSnapshot(r.cfg.Profile)
+	} else {
+		jpeg, err = game.Snapshot(r.cfg.Profile, r.index)
+	}
+	if err != nil {
+		r.log.Warn("Error grabbing snapshot", zap.Error(err))
+		if r.snapshot != nil {
+			return r.snapshot, r.snapshotAt, nil // a stale preview beats none
+		}
+		return nil, time.Time{}, err
+	}
+
+	r.snapshot = jpeg
+	r.snapshotAt = at
+	return jpeg, at, nil
+}
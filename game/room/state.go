@@ -0,0 +1,205 @@
+package room
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	game "zoomgaming/game"
+	"zoomgaming/logging"
+	"zoomgaming/metrics"
+	pb "zoomgaming/proto"
+	utils "zoomgaming/utils"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Room events are pushed to every connection across its RoomState data channel
+
+A connection receives a snapshot of the room when its channel opens, and every change after that:
+membership, seat assignments, host changes, game switches, stream health, countdowns and recording.
+
+*/
+
+const healthInterval = 5 * time.Second // how often stream health is reported
+
+// Send a room event to every connection whose RoomState channel is open
+func (r *room) publish(evt *pb.RoomEvent) {
+	for conn := range r.listeners {
+		err := conn.Send(evt)
+		utils.WarnOnError(r.connLog(conn), err, "Error publishing room event")
+	}
+}
+
+// Start publishing room events to a connection, beginning with a snapshot of the room
+func (r *room) openState(conn rtc.WebRTC, msgs <-chan proto.Message) {
+
+	r.mu.Lock()
+
+	if player, prs := r.members[conn]; prs {
+		r.listeners[conn] = struct{}{}
+
+		snapshot := []*pb.RoomEvent{
+			r.membersEvent(),
+			{Event: &pb.RoomEvent_SeatAssignment{
+				SeatAssignment: &pb.SeatAssignment{Role: player.Role, Seat: uint32(player.Seat)},
+			}},
+			{Event: &pb.RoomEvent_GameSwitch{
+				GameSwitch: &pb.GameSwitch{GameId: r.typ.String()},
+			}},
+		}
+		snapshot = append(snapshot, r.voiceEvents()...)
+		snapshot = append(snapshot, r.recordingEvents()...)
+		if r.health != nil {
+			snapshot = append(snapshot, &pb.RoomEvent{
+				Event: &pb.RoomEvent_StreamHealth{StreamHealth: r.health},
+			})
+		}
+
+		for _, evt := range snapshot {
+			err := conn.Send(evt)
+			utils.WarnOnError(r.connLog(conn), err, "Error sending room snapshot")
+		}
+	}
+
+	r.mu.Unlock()
+
+	// The browser only asks for clips on this channel
+	for msg := range msgs {
+		if _, ok := msg.(*pb.RoomEvent).GetEvent().(*pb.RoomEvent_ClipRequest); ok {
+			go r.sendClip(conn)
+		}
+	}
+}
+
+// Report how much the game's streams sent, until the room shuts down
+func (r *room) monitorStreams() {
+
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.quit:
+			return
+		case <-ticker.C:
+		}
+
+		video := atomic.SwapUint64(&r.videoBytes, 0)
+		audio := atomic.SwapUint64(&r.audioBytes, 0)
+		seconds := uint64(healthInterval / time.Second)
+
+		health := &pb.StreamHealth{
+			VideoLive: video > 0,
+			AudioLive: audio > 0,
+			VideoKbps: uint32(video * 8 / 1000 / seconds),
+			AudioKbps: uint32(audio * 8 / 1000 / seconds),
+		}
+
+		r.mu.Lock()
+		r.health = health
+		r.publish(&pb.RoomEvent{
+			Event: &pb.RoomEvent_StreamHealth{StreamHealth: health},
+		})
+		r.mu.Unlock()
+	}
+}
+
+// Count an encoder exiting while the room is open
+func (r *room) watchEncoder(stream game.Stream, name string) {
+
+	select {
+	case <-r.quit:
+		return
+	case <-stream.Exited():
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.closed {
+		r.log.Error("Encoder exited", logging.Game(r.typ.String()), zap.String("stream", name))
+		metrics.EncoderExits.WithLabelValues(r.typ.String(), name).Inc()
+	}
+}
+
+// Count a game crashing, i.e. exiting while it is still the room's game
+func (r *room) watchGame(g game.Game, typ game.GameType) {
+
+	select {
+	case <-r.quit:
+		return
+	case <-g.Exited():
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.closed && r.game == g {
+		r.log.Error("Game exited", logging.Game(typ.String()))
+		metrics.GameCrashes.WithLabelValues(typ.String()).Inc()
+	}
+}
+
+// Count down once a second on every client, replacing any countdown that is running
+func (r *room) StartCountdown(seconds uint32) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.countdown != nil {
+		close(r.countdown)
+		r.countdown = nil
+		if seconds == 0 {
+			r.publish(&pb.RoomEvent{
+				Event: &pb.RoomEvent_Countdown{Countdown: &pb.Countdown{Cancelled: true}},
+			})
+		}
+	}
+
+	if seconds == 0 || r.closed {
+		return
+	}
+
+	cancel := make(chan struct{})
+	r.countdown = cancel
+	go r.runCountdown(seconds, cancel)
+}
+
+func (r *room) runCountdown(remaining uint32, cancel chan struct{}) {
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		r.mu.Lock()
+		select {
+		case <-cancel:
+			r.mu.Unlock()
+			return
+		default:
+		}
+		r.publish(&pb.RoomEvent{
+			Event: &pb.RoomEvent_Countdown{Countdown: &pb.Countdown{Remaining: remaining}},
+		})
+		if remaining == 0 {
+			r.countdown = nil
+			r.mu.Unlock()
+			return
+		}
+		r.mu.Unlock()
+
+		remaining--
+
+		select {
+		case <-cancel:
+			return
+		case <-r.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package room
+
+import (
+	game "zoomgaming/game"
+	"zoomgaming/rtmp"
+	rtc "zoomgaming/webrtc"
+)
+
+// A snapshot of the room, for monitoring
+type Stats struct {
+	Game      string
+	Host      string
+	Players   []Player
+	Input     map[game.PlayerIndex](game.InputStats) // by seat, for every seat that has sent input to the current game
+	Broadcast *rtmp.Status                           // nil unless the room is pushed to rtmp
+	Viewers   []rtc.ConnectionStats                  // of everyone watching over WHEP
+}
+
+func (r *room) Stats() Stats {
+
+	players := r.Players()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Stats{
+		Game:      r.typ.String(),
+		Host:      r.host,
+		Players:   players,
+		Input:     r.game.InputStats(),
+		Broadcast: r.broadcastStatus(),
+		Viewers:   r.viewerStats(),
+	}
+}
@@ -0,0 +1,187 @@
+package room
+
+import (
+	"github.com/pion/webrtc/v3"
+
+	game "zoomgaming/game"
+	pb "zoomgaming/proto"
+	utils "zoomgaming/utils"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Voice chat between the players in a room
+
+Every connection is given its own voice track per seat when it joins, so that the browser
+can receive them all in its first and only offer. A player's microphone is forwarded to the
+track for their seat on every other connection, unless that listener muted them.
+
+Microphones are only forwarded while the player is talking, as signaled by push-to-talk,
+and not at all while the host has voice chat turned off. Rooms with a commentary track
+also mix every forwarded microphone into it. Who hears whom is worked out again whenever any
+of this changes, so that forwarding a packet does not take the room's lock.
+
+*/
+
+// Turn voice chat on or off for everyone in the room
+func (r *room) SetVoice(enabled bool) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.voice == enabled {
+		return
+	}
+	r.voice = enabled
+	r.updateVoiceRoutes()
+
+	r.publish(&pb.RoomEvent{
+		Event: &pb.RoomEvent_VoiceSwitch{VoiceSwitch: &pb.VoiceSwitch{Enabled: enabled}},
+	})
+}
+
+// One voice track per seat, for a single listener
+func newVoiceTracks(seats int) ([]*webrtc.TrackLocalStaticRTP, error) {
+
+	tracks := make([]*webrtc.TrackLocalStaticRTP, 0, seats)
+	for idx := game.Player1; int(idx) <= seats; idx++ {
+		track, err := webrtc.NewTrackLocalStaticRTP(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus}, "voice", idx.String())
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// Forward a connection's microphones to the rest of the room until it closes
+func (r *room) watchVoice(conn rtc.WebRTC) {
+	for mic := range conn.Broadcast() {
+		go r.relayVoice(conn, mic)
+	}
+}
+
+func (r *room) relayVoice(conn rtc.WebRTC, mic <-chan []byte) {
+	for pckt := range mic {
+
+		routes, _ := r.voiceRoutes.Load().(map[rtc.WebRTC](voiceRoute))
+		route, prs := routes[conn]
+		if !prs {
+			continue
+		}
+
+		if r.mixer != nil {
+			r.mixer.Write(route.seat, pckt)
+		}
+		for _, track := range route.tracks {
+			track.Write(pckt)
+		}
+	}
+}
+
+// Where a speaker's microphone is forwarded to
+type voiceRoute struct {
+	seat   game.PlayerIndex
+	tracks []*webrtc.TrackLocalStaticRTP // the track for the speaker's seat on each listener who hears them
+}
+
+// Work out who hears whom, must hold r.mu
+//
+// Called whenever voice chat is switched, or a member joins, leaves, changes seats, talks or mutes someone
+func (r *room) updateVoiceRoutes() {
+
+	routes := make(map[rtc.WebRTC](voiceRoute))
+	if r.voice {
+		for conn := range r.talking {
+			speaker, prs := r.members[conn]
+			if !prs || speaker.Seat == game.PlayerUndefined {
+				continue
+			}
+			route := voiceRoute{seat: speaker.Seat}
+			for listener, tracks := range r.voiceTracks {
+				if _, muted := r.mutes[listener][speaker.ID]; muted || listener == conn || int(speaker.Seat) > len(tracks) {
+					continue
+				}
+				route.tracks = append(route.tracks, tracks[speaker.Seat-1])
+			}
+			routes[conn] = route
+		}
+	}
+
+	r.voiceRoutes.Store(routes)
+}
+
+// Apply voice controls sent by a connection until it closes
+func (r *room) handleVoiceControls(conn rtc.WebRTC) {
+	for ctrl := range conn.VoiceControls() {
+		if err := r.applyVoiceControl(conn, ctrl); err != nil {
+			err = conn.Signal(rtc.ErrorEvent(err))
+			utils.WarnOnError(r.connLog(conn), err, "Error refusing voice control")
+		}
+	}
+}
+
+func (r *room) applyVoiceControl(conn rtc.WebRTC, ctrl *pb.VoiceControl) error {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	player, prs := r.members[conn]
+	if !prs {
+		return nil
+	}
+
+	switch c := ctrl.GetControl().(type) {
+	case *pb.VoiceControl_Mute:
+		if r.connByID(c.Mute) == nil {
+			return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "no client %s", c.Mute)
+		}
+		if r.mutes[conn] == nil {
+			r.mutes[conn] = make(map[string]struct{})
+		}
+		r.mutes[conn][c.Mute] = struct{}{}
+	case *pb.VoiceControl_Unmute:
+		delete(r.mutes[conn], c.Unmute)
+	case *pb.VoiceControl_Talking:
+		if c.Talking && player.Seat == game.PlayerUndefined {
+			return rtc.NewSignalingError(pb.SignalingError_CODE_INPUT_REJECTED, "spectators cannot talk")
+		}
+		if _, talking := r.talking[conn]; talking == c.Talking {
+			return nil
+		}
+		if c.Talking {
+			r.talking[conn] = struct{}{}
+		} else {
+			delete(r.talking, conn)
+		}
+		r.publish(&pb.RoomEvent{
+			Event: &pb.RoomEvent_VoiceActivity{
+				VoiceActivity: &pb.VoiceActivity{ClientId: player.ID, Talking: c.Talking},
+			},
+		})
+	default:
+		return rtc.NewSignalingError(pb.SignalingError_CODE_UNSPECIFIED, "unexpected voice control: %T", c)
+	}
+
+	r.updateVoiceRoutes()
+	return nil
+}
+
+// Voice chat as a connection should see it when its RoomState channel opens
+func (r *room) voiceEvents() []*pb.RoomEvent {
+
+	events := []*pb.RoomEvent{
+		{Event: &pb.RoomEvent_VoiceSwitch{VoiceSwitch: &pb.VoiceSwitch{Enabled: r.voice}}},
+	}
+	for conn := range r.talking {
+		events = append(events, &pb.RoomEvent{
+			Event: &pb.RoomEvent_VoiceActivity{
+				VoiceActivity: &pb.VoiceActivity{ClientId: r.members[conn].ID, Talking: true},
+			},
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,76 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/pion/webrtc/v3"
+
+	game "zoomgaming/game"
+	pb "zoomgaming/proto"
+	rtc "zoomgaming/webrtc"
+)
+
+// The tracks a speaker is forwarded to, nil if they are not heard at all
+func (r *room) testRoute(conn rtc.WebRTC) *voiceRoute {
+
+	routes, _ := r.voiceRoutes.Load().(map[rtc.WebRTC](voiceRoute))
+	route, prs := routes[conn]
+	if !prs {
+		return nil
+	}
+	return &route
+}
+
+func TestVoiceRoutes(t *testing.T) {
+
+	r := newTestRoom(game.SpaceTime, 4)
+	r.voice = true
+	r.talking = make(map[rtc.WebRTC]struct{})
+	r.mutes = make(map[rtc.WebRTC](map[string]struct{}))
+	r.voiceTracks = make(map[rtc.WebRTC]([]*webrtc.TrackLocalStaticRTP))
+
+	alice := r.addTestConn("alice", game.Player1)
+	bob := r.addTestConn("bob", game.Player2)
+	carol := r.addTestConn("carol", game.PlayerUndefined)
+	for _, conn := range []rtc.WebRTC{alice, bob, carol} {
+		tracks, err := newVoiceTracks(r.typ.Seats())
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.voiceTracks[conn] = tracks
+	}
+	talk := &pb.VoiceControl{Control: &pb.VoiceControl_Talking{Talking: true}}
+
+	checkCode(t, "spectator talking", r.applyVoiceControl(carol, talk), pb.SignalingError_CODE_INPUT_REJECTED)
+	if r.testRoute(alice) != nil {
+		t.Error("a player is heard before talking")
+	}
+
+	checkCode(t, "talking", r.applyVoiceControl(alice, talk), pb.SignalingError_CODE_UNSPECIFIED)
+	route := r.testRoute(alice)
+	if route == nil || route.seat != game.Player1 || len(route.tracks) != 2 {
+		t.Fatalf("talking player is not heard by everyone else: %+v", route)
+	}
+	for _, track := range route.tracks {
+		if track != r.voiceTracks[bob][0] && track != r.voiceTracks[carol][0] {
+			t.Errorf("talking player is forwarded to the wrong track: %s", track.StreamID())
+		}
+	}
+
+	mute := &pb.VoiceControl{Control: &pb.VoiceControl_Mute{Mute: "alice"}}
+	checkCode(t, "mute", r.applyVoiceControl(carol, mute), pb.SignalingError_CODE_UNSPECIFIED)
+	if route := r.testRoute(alice); route == nil || len(route.tracks) != 1 || route.tracks[0] != r.voiceTracks[bob][0] {
+		t.Errorf("muted player is still heard by the listener who muted them: %+v", route)
+	}
+
+	r.SetVoice(false)
+	if r.testRoute(alice) != nil {
+		t.Error("a player is heard with voice chat turned off")
+	}
+
+	r.SetVoice(true)
+	r.sendAssignment(alice, game.PlayerUndefined)
+	if r.testRoute(alice) != nil {
+		t.Error("a player is still heard after moving to the spectators")
+	}
+}
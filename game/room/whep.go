@@ -0,0 +1,126 @@
+package room
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"zoomgaming/logging"
+	"zoomgaming/metrics"
+	pb "zoomgaming/proto"
+	rtc "zoomgaming/webrtc"
+)
+
+/**
+
+Viewers watch the game's video and audio over WHEP, without joining the room
+
+Viewers are not members: they are not listed in RoomMembers, cannot chat or talk, and do not keep
+the room open. They take spectator slots, since each holds a peer connection like a spectator does.
+
+*/
+
+// Answer the offer of a viewer, identified by client id, the viewer is removed once its connection closes
+func (r *room) WatchWHEP(id string, offer string) (rtc.WHEP, error) {
+
+	r.mu.Lock()
+	err := r.checkViewer(id)
+	typ := r.typ
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Gathering candidates takes a while, let the room carry on meanwhile
+	viewer, err := rtc.NewWHEP(offer, r.videoTrack, r.audioTrack, metrics.TimeToAnswer.WithLabelValues(typ.String()), r.log)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkViewer(id); err != nil {
+		viewer.Close()
+		return nil, err
+	}
+
+	r.viewers[viewer] = id
+	r.log.Info("Viewer started watching over WHEP", logging.Peer(viewer.ID()), logging.Client(id), zap.Int("viewers", len(r.viewers)))
+
+	go func() {
+		<-viewer.Done()
+		r.mu.Lock()
+		delete(r.viewers, viewer)
+		r.mu.Unlock()
+		r.log.Info("Viewer stopped watching over WHEP", logging.Peer(viewer.ID()))
+	}()
+
+	return viewer, nil
+}
+
+// Stop the viewer with a connection id, for the client who started watching with it or the host
+func (r *room) StopWHEP(id string, client string) error {
+
+	r.mu.Lock()
+	var viewer rtc.WHEP
+	var owner string
+	for v, c := range r.viewers {
+		if v.ID() == id {
+			viewer, owner = v, c
+		}
+	}
+	host := r.host
+	r.mu.Unlock()
+
+	if viewer == nil {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_FOUND, "no viewer %s", id)
+	}
+	if client != owner && client != host {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_NOT_HOST, "only the host can stop another client's viewer")
+	}
+	return viewer.Close()
+}
+
+// Stop everyone a client is watching over WHEP with, must hold r.mu
+func (r *room) stopViewers(id string) {
+	for viewer, client := range r.viewers {
+		if client == id {
+			viewer.Close()
+		}
+	}
+}
+
+// Must hold r.mu
+func (r *room) checkViewer(id string) error {
+
+	if r.closed {
+		return errors.New("room closed")
+	}
+	if _, prs := r.banned[id]; prs {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_BANNED, "banned from the room")
+	}
+	if r.locked && id != r.host {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_ROOM_LOCKED, "the room is locked")
+	}
+	if r.spectatorCount() >= r.cfg.MaxSpectators {
+		return rtc.NewSignalingError(pb.SignalingError_CODE_SPECTATORS_FULL,
+			"all %d spectator slots are taken", r.cfg.MaxSpectators)
+	}
+	return nil
+}
+
+// Spectators and viewers, who share the room's spectator slots
+func (r *room) spectatorCount() int {
+	return len(r.spectators) + len(r.viewers)
+}
+
+// Must hold r.mu
+func (r *room) viewerStats() []rtc.ConnectionStats {
+
+	stats := make([]rtc.ConnectionStats, 0, len(r.viewers))
+	for viewer := range r.viewers {
+		stats = append(stats, viewer.Stats())
+	}
+	return stats
+}
@@ -0,0 +1,49 @@
+package room
+
+import (
+	"testing"
+
+	game "zoomgaming/game"
+	pb "zoomgaming/proto"
+)
+
+func TestStopWHEP(t *testing.T) {
+
+	r := newTestRoom(game.SpaceTime, 4)
+	r.addTestConn("host", game.Player1)
+	r.host = "host"
+	a := &fakeViewer{id: "a"}
+	b := &fakeViewer{id: "b"}
+	r.viewers[a] = "alice"
+	r.viewers[b] = "bob"
+
+	checkCode(t, "no viewer", r.StopWHEP("c", "alice"), pb.SignalingError_CODE_NOT_FOUND)
+	checkCode(t, "someone else's viewer", r.StopWHEP("b", "alice"), pb.SignalingError_CODE_NOT_HOST)
+	if b.closed {
+		t.Error("a client stopped someone else's viewer")
+	}
+
+	checkCode(t, "own viewer", r.StopWHEP("a", "alice"), pb.SignalingError_CODE_UNSPECIFIED)
+	checkCode(t, "host", r.StopWHEP("b", "host"), pb.SignalingError_CODE_UNSPECIFIED)
+	if !a.closed || !b.closed {
+		t.Error("viewers were not stopped by their owner or the host")
+	}
+}
+
+func TestCheckViewer(t *testing.T) {
+
+	r := newTestRoom(game.SpaceTime, 1)
+	r.host = "host"
+	r.banned = map[string](struct{}){"mallory": {}}
+
+	checkCode(t, "viewer", r.checkViewer("alice"), pb.SignalingError_CODE_UNSPECIFIED)
+	checkCode(t, "banned", r.checkViewer("mallory"), pb.SignalingError_CODE_BANNED)
+
+	r.locked = true
+	checkCode(t, "locked", r.checkViewer("alice"), pb.SignalingError_CODE_ROOM_LOCKED)
+	checkCode(t, "host of a locked room", r.checkViewer("host"), pb.SignalingError_CODE_UNSPECIFIED)
+
+	r.locked = false
+	r.viewers[&fakeViewer{id: "a"}] = "alice"
+	checkCode(t, "full", r.checkViewer("bob"), pb.SignalingError_CODE_SPECTATORS_FULL)
+}
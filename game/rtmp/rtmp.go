@@ -0,0 +1,253 @@
+package rtmp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"zoomgaming/remux"
+)
+
+/**
+
+Pushes a room's audio and video to an RTMP ingest, e.g. a broadcaster's
+
+The broadcast is fed the same RTP packets as the room's tracks and forwards them to an ffmpeg process, see remux,
+which remuxes the H264 video into FLV without re-encoding. FLV cannot carry Opus, so the audio is transcoded to AAC.
+
+When ffmpeg exits, e.g. because the ingest dropped the connection, it is started again after a backoff
+that doubles up to maxBackoff, until the broadcast is stopped.
+
+*/
+
+var (
+	minBackoff  = 1 * time.Second
+	maxBackoff  = 30 * time.Second
+	stableAfter = 30 * time.Second // live for this long, the next failure starts over from minBackoff
+)
+
+var command = exec.CommandContext // replaced in tests, which have no ffmpeg or ingest
+
+type State string
+
+const (
+	Connecting   State = "connecting"   // ffmpeg is starting, or has not sent anything yet
+	Live         State = "live"         // ffmpeg is sending to the ingest
+	Reconnecting State = "reconnecting" // waiting out the backoff after a failure
+	Stopped      State = "stopped"
+)
+
+// How a broadcast is going, for room diagnostics
+type Status struct {
+	URL        string // without the stream key
+	State      State
+	StartedAt  time.Time
+	LiveSince  time.Time // zero unless live
+	Reconnects int
+	LastError  string // of the latest failure, kept once reconnected
+}
+
+type Broadcast interface {
+	WriteVideo([]byte) // an H264 rtp packet
+	WriteAudio([]byte) // an Opus rtp packet
+	Status() Status
+	Stop()
+}
+
+type broadcast struct {
+	url    string
+	dir    string // holds the sdp file
+	input  *remux.Input
+	cancel context.CancelFunc
+	done   chan struct{} // closed once the last ffmpeg exits after Stop
+	log    *zap.Logger
+
+	mu     *sync.Mutex // protects status
+	status Status
+}
+
+// Start pushing a room to an rtmp url
+//
+// ffmpeg listens for the room's rtp on ports picked from the room's slot on the server, see remux
+func NewBroadcast(rtmpURL string, roomIndex int, log *zap.Logger) (Broadcast, error) {
+
+	if u, err := url.Parse(rtmpURL); err != nil || (u.Scheme != "rtmp" && u.Scheme != "rtmps") || u.Host == "" {
+		return nil, errors.New("not an rtmp url")
+	}
+
+	dir, err := ioutil.TempDir("", "rtmp-")
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := remux.NewInput(dir, 10004+roomIndex*4)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &broadcast{
+		url:    rtmpURL,
+		dir:    dir,
+		input:  input,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		log:    log.With(zap.String("rtmp", Redact(rtmpURL))),
+		mu:     &sync.Mutex{},
+		status: Status{URL: Redact(rtmpURL), State: Connecting, StartedAt: time.Now()},
+	}
+
+	go b.run(ctx)
+
+	return b, nil
+}
+
+func (b *broadcast) WriteVideo(pckt []byte) {
+	b.input.WriteVideo(pckt)
+}
+
+func (b *broadcast) WriteAudio(pckt []byte) {
+	b.input.WriteAudio(pckt)
+}
+
+func (b *broadcast) Status() Status {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.status
+}
+
+func (b *broadcast) Stop() {
+
+	b.cancel()
+	<-b.done
+	b.input.Close()
+	os.RemoveAll(b.dir)
+
+	b.update(func(s *Status) {
+		s.State = Stopped
+		s.LiveSince = time.Time{}
+	})
+}
+
+// Run ffmpeg until the broadcast is stopped, starting it again whenever it exits
+func (b *broadcast) run(ctx context.Context) {
+
+	defer close(b.done)
+
+	backoff := minBackoff
+	for {
+		started := time.Now()
+		err := b.push(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) > stableAfter {
+			backoff = minBackoff
+		}
+
+		b.log.Warn("RTMP push failed, reconnecting", zap.Error(err), zap.Duration("backoff", backoff))
+		b.update(func(s *Status) {
+			s.State = Reconnecting
+			s.LiveSince = time.Time{}
+			s.Reconnects++
+			s.LastError = err.Error()
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		b.update(func(s *Status) { s.State = Connecting })
+	}
+}
+
+// Run ffmpeg once, the broadcast is live once ffmpeg reports progress
+func (b *broadcast) push(ctx context.Context) error {
+
+	args := append([]string{"-loglevel", "error", "-nostats", "-progress", "pipe:1"}, b.input.Args()...)
+	cmd := command(ctx, "ffmpeg", append(args,
+		"-map", "0", "-c:v", "copy", "-c:a", "aac", "-b:a", "128k", "-ar", "48000",
+		"-f", "flv", b.url)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if scanner.Text() == "progress=continue" {
+			b.update(func(s *Status) {
+				if s.State != Live {
+					s.State = Live
+					s.LiveSince = time.Now()
+					b.log.Info("RTMP push is live")
+				}
+			})
+		}
+	}
+
+	err = cmd.Wait()
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return errors.New(lastLine(msg))
+	}
+	if err == nil {
+		err = errors.New("ffmpeg exited")
+	}
+	return err
+}
+
+func (b *broadcast) update(f func(*Status)) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	f(&b.status)
+}
+
+// An rtmp url without its stream key, the last element of the path, which is a secret
+func Redact(rtmpURL string) string {
+
+	u, err := url.Parse(rtmpURL)
+	if err != nil {
+		return "invalid url"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	if i := strings.LastIndex(u.Path, "/"); i > 0 {
+		u.Path = u.Path[:i] + "/***"
+		u.RawPath = u.Path // keep the stars unescaped
+	}
+	return u.String()
+}
+
+func lastLine(s string) string {
+	return s[strings.LastIndex(s, "\n")+1:]
+}
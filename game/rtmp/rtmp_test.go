@@ -0,0 +1,149 @@
+package rtmp
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Stands in for ffmpeg: connects to the ingest, reports progress, and fails once the ingest drops the connection
+func TestHelperFFmpeg(t *testing.T) {
+
+	if os.Getenv("RTMP_TEST_HELPER") != "1" {
+		return
+	}
+	defer os.Exit(1)
+
+	u, err := url.Parse(os.Args[len(os.Args)-1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	conn, err := net.Dial("tcp", u.Host)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println("progress=continue")
+
+	ioutil.ReadAll(conn)
+	fmt.Fprintln(os.Stderr, "connection dropped")
+}
+
+func helperCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=TestHelperFFmpeg", "--"}, args...)...)
+	cmd.Env = append(os.Environ(), "RTMP_TEST_HELPER=1")
+	return cmd
+}
+
+func TestBroadcastReconnects(t *testing.T) {
+
+	command = helperCommand
+	minBackoff, maxBackoff = 100*time.Millisecond, 150*time.Millisecond
+	defer func() {
+		command = exec.CommandContext
+		minBackoff, maxBackoff = 1*time.Second, 30*time.Second
+	}()
+
+	ingest, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ingest.Close()
+
+	conns := make(chan net.Conn)
+	go func() {
+		for {
+			conn, err := ingest.Accept()
+			if err != nil {
+				close(conns)
+				return
+			}
+			conns <- conn
+		}
+	}()
+
+	b, err := NewBroadcast(fmt.Sprintf("rtmp://%s/live/secret", ingest.Addr()), 40, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b.Status(); s.State != Connecting || s.StartedAt.IsZero() {
+		t.Errorf("new broadcast has status %+v", s)
+	}
+
+	// Each connection goes live, then fails when the ingest drops it and is made again after the backoff
+	var dropped time.Time
+	for i, backoff := range []time.Duration{0, 100 * time.Millisecond, 150 * time.Millisecond, 150 * time.Millisecond} {
+
+		conn := accept(t, conns)
+		if i > 0 {
+			if waited := time.Since(dropped); waited < backoff {
+				t.Errorf("reconnect %d after %s, want a backoff of at least %s", i, waited, backoff)
+			}
+		}
+
+		s := waitFor(t, b, Live)
+		if s.Reconnects != i {
+			t.Errorf("live with %d reconnects, want %d", s.Reconnects, i)
+		}
+		if s.LiveSince.IsZero() {
+			t.Error("live without a LiveSince")
+		}
+		if s.URL != fmt.Sprintf("rtmp://%s/live/***", ingest.Addr()) {
+			t.Errorf("status has url %s, want it redacted", s.URL)
+		}
+
+		dropped = time.Now()
+		conn.Close()
+
+		s = waitFor(t, b, Reconnecting)
+		if s.LastError != "connection dropped" {
+			t.Errorf("reconnecting after %q, want the error ffmpeg printed", s.LastError)
+		}
+		if !s.LiveSince.IsZero() {
+			t.Error("reconnecting with a LiveSince")
+		}
+	}
+
+	accept(t, conns)
+	waitFor(t, b, Live)
+
+	b.Stop()
+	if s := b.Status(); s.State != Stopped || !s.LiveSince.IsZero() {
+		t.Errorf("stopped broadcast has status %+v", s)
+	}
+}
+
+func accept(t *testing.T, conns <-chan net.Conn) net.Conn {
+	t.Helper()
+
+	select {
+	case conn := <-conns:
+		return conn
+	case <-time.After(10 * time.Second):
+		t.Fatal("ffmpeg did not connect to the ingest")
+		return nil
+	}
+}
+
+func waitFor(t *testing.T, b Broadcast, state State) Status {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if s := b.Status(); s.State == state {
+			return s
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("broadcast is %s, want %s", b.Status().State, state)
+	return Status{}
+}
@@ -0,0 +1,20 @@
+package utils
+
+import "time"
+
+// NTP timestamps, as carried by RTCP sender and receiver reports, count seconds since 1900
+// in the upper 32 bits, and fractions of a second in the lower 32
+
+const ntpEpochOffset = 2208988800 // seconds from 1900 to 1970
+
+func FromNTP(ntp uint64) time.Time {
+	secs := int64(ntp>>32) - ntpEpochOffset
+	nanos := int64((ntp & 0xffffffff) * uint64(time.Second) >> 32)
+	return time.Unix(secs, nanos)
+}
+
+func ToNTP(t time.Time) uint64 {
+	secs := uint64(t.Unix() + ntpEpochOffset)
+	frac := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return secs<<32 | frac
+}
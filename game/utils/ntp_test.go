@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNTP(t *testing.T) {
+
+	// 1970 in NTP, and back
+	if got := FromNTP(ntpEpochOffset << 32); !got.Equal(time.Unix(0, 0)) {
+		t.Errorf("NTP epoch offset is %s, want the unix epoch", got)
+	}
+	if got := ToNTP(time.Unix(0, 0)); got != ntpEpochOffset<<32 {
+		t.Errorf("unix epoch is %x in NTP, want %x", got, uint64(ntpEpochOffset<<32))
+	}
+
+	now := time.Now()
+	if d := FromNTP(ToNTP(now)).Sub(now); d < -time.Nanosecond || d > time.Nanosecond {
+		t.Errorf("round trip through NTP is off by %s", d)
+	}
+}
@@ -2,19 +2,21 @@ package utils
 
 import (
 	"fmt"
-	"log"
+
+	"go.uber.org/zap"
 )
 
 // Helper Functions
-func FailOnError(err error, format string, a ...interface{}) {
+
+// Panic with the error, for constructors that recover from panics and return the error instead
+func PanicOnError(err error, format string, a ...interface{}) {
 	if err != nil {
-		log.Fatalf(format, append(a, err)...)
 		panic(fmt.Sprintf(format, append(a, err)...))
 	}
 }
 
-func WarnOnError(err error, format string, a ...interface{}) {
+func WarnOnError(log *zap.Logger, err error, msg string, fields ...zap.Field) {
 	if err != nil {
-		log.Printf(format, append(a, err)...)
+		log.Warn(msg, append(fields, zap.Error(err))...)
 	}
 }
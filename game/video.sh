@@ -1,6 +1,11 @@
+# usage: video.sh <display> <port> [size] [framerate] [bitrate], e.g. video.sh :99 5004 1280x720 60 2400k
+SIZE=${3:-1280x720}
+FRAMERATE=${4:-60}
+BITRATE=${5:-2400k}
+
 # ffmpeg -loglevel debug -threads 2 -filter_threads 2 \
 ffmpeg -hwaccel cuda -hwaccel_output_format cuda -threads 2 -filter_threads 2 \
--f x11grab -draw_mouse 0 -s 1280x720 -framerate 60 -i $1 \
--b:v 2400k -minrate:v 2400k -maxrate:v 2400k -bufsize:v 2400k \
+-f x11grab -draw_mouse 0 -s $SIZE -framerate $FRAMERATE -i $1 \
+-b:v $BITRATE -minrate:v $BITRATE -maxrate:v $BITRATE -bufsize:v $BITRATE \
 -c h264_nvenc -preset p4 -tune ll -profile high \
 -f rtp rtp://127.0.0.1:$2
@@ -17,11 +17,13 @@ type DataChannelLabel int // Represents a unique data chhanel
 
 const (
 	GameInput DataChannelLabel = iota + 1
-	// ChatRoom
+	RoomState
+	ChatRoom
+	Latency
 )
 
 func (label DataChannelLabel) String() string {
-	return [...]string{"", "GameInput", "ChatRoom"}[label]
+	return [...]string{"", "GameInput", "RoomState", "ChatRoom", "Latency"}[label]
 }
 
 var defaultRTCConfiguration = webrtc.Configuration{
@@ -38,12 +40,35 @@ var dcConfigs = map[DataChannelLabel](*webrtc.DataChannelInit){
 		Negotiated: func(b bool) *bool { return &b }(true),
 		ID:         func(i uint16) *uint16 { return &i }(0),
 	},
+	RoomState: &webrtc.DataChannelInit{
+		Ordered:    func(b bool) *bool { return &b }(true),
+		Negotiated: func(b bool) *bool { return &b }(true),
+		ID:         func(i uint16) *uint16 { return &i }(1),
+	},
+	ChatRoom: &webrtc.DataChannelInit{
+		Ordered:    func(b bool) *bool { return &b }(true),
+		Negotiated: func(b bool) *bool { return &b }(true),
+		ID:         func(i uint16) *uint16 { return &i }(2),
+	},
+	// Probes are only useful while fresh, never retransmit or wait for one another
+	Latency: &webrtc.DataChannelInit{
+		Ordered:        func(b bool) *bool { return &b }(false),
+		MaxRetransmits: func(i uint16) *uint16 { return &i }(0),
+		Negotiated:     func(b bool) *bool { return &b }(true),
+		ID:             func(i uint16) *uint16 { return &i }(3),
+	},
 }
 
 var mapping = map[DataChannelLabel](pref.MessageType){
 	GameInput: (*pb.InputEvent)(nil).ProtoReflect().Type(),
+	RoomState: (*pb.RoomEvent)(nil).ProtoReflect().Type(),
+	ChatRoom:  (*pb.ChatMessage)(nil).ProtoReflect().Type(),
+	Latency:   (*pb.LatencyProbe)(nil).ProtoReflect().Type(),
 }
 
 var reverseMapping = map[pref.MessageType](DataChannelLabel){
-	(*pb.InputEvent)(nil).ProtoReflect().Type(): GameInput,
+	(*pb.InputEvent)(nil).ProtoReflect().Type():   GameInput,
+	(*pb.RoomEvent)(nil).ProtoReflect().Type():    RoomState,
+	(*pb.ChatMessage)(nil).ProtoReflect().Type():  ChatRoom,
+	(*pb.LatencyProbe)(nil).ProtoReflect().Type(): Latency,
 }
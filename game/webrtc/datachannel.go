@@ -1,9 +1,8 @@
 package webrtc
 
 import (
-	"log"
-
 	webrtc "github.com/pion/webrtc/v3"
+	"go.uber.org/zap"
 
 	proto "google.golang.org/protobuf/proto"
 	pref "google.golang.org/protobuf/reflect/protoreflect"
@@ -21,7 +20,9 @@ type dataChannel struct {
 	receiver chan proto.Message
 }
 
-func NewDataChannel(label DataChannelLabel, dc_impl *webrtc.DataChannel) DataChannel {
+func NewDataChannel(label DataChannelLabel, dc_impl *webrtc.DataChannel, log *zap.Logger) DataChannel {
+
+	log = log.With(zap.Stringer("channel", label))
 
 	dc := &dataChannel{
 		dc:      dc_impl,
@@ -31,18 +32,17 @@ func NewDataChannel(label DataChannelLabel, dc_impl *webrtc.DataChannel) DataCha
 	dc_impl.OnOpen(func() {
 		dc.receiver = make(chan proto.Message, 1024)
 		dc.updates <- dc.receiver
-		// log.Printf("Data Channel '%s'-'%d' open.\n", dc_impl.Label(), *(dc_impl.ID()))
+		log.Debug("Data channel open", zap.Uint16p("id", dc_impl.ID()))
 	})
 
 	dc_impl.OnMessage(func(msg webrtc.DataChannelMessage) {
 
 		b := msg.Data
-		// log.Printf("Message received on DataChannel '%s'", dc_impl.Label())
 
 		var pb_msg pref.Message = mapping[label].New()
 		err := proto.Unmarshal(b, pb_msg.Interface())
 		if err != nil {
-			log.Printf("error unmarshalling")
+			log.Warn("Error unmarshalling message", zap.Error(err))
 			return
 		}
 
@@ -52,7 +52,7 @@ func NewDataChannel(label DataChannelLabel, dc_impl *webrtc.DataChannel) DataCha
 
 	dc_impl.OnClose(func() {
 		close(dc.receiver)
-		log.Println("Data channel closed")
+		log.Debug("Data channel closed")
 	})
 
 	return dc
@@ -0,0 +1,175 @@
+package webrtc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+
+	zutils "zoomgaming/utils"
+)
+
+/**
+
+An interceptor that counts the rtp sent to one peer, and reads the rtcp the peer sends back:
+receiver reports for loss, jitter and round trips, and requests to resend packets or send a keyframe.
+
+pion's own GetStats does not cover rtp streams yet.
+
+*/
+
+type statsInterceptor struct {
+	interceptor.NoOp
+	mu          *sync.Mutex
+	streams     map[uint32](*streamReport) // each local stream, by ssrc
+	packetsSent uint64
+	nacks       uint64
+	plis        uint64
+	rtt         time.Duration
+	remb        uint64
+}
+
+// What the browser last reported about one local stream
+type streamReport struct {
+	clockRate    uint32
+	lost         uint32
+	fractionLost float64
+	jitter       time.Duration
+}
+
+// Totals across every media track sent to a peer
+type RTPStats struct {
+	PacketsSent  uint64
+	NACKs        uint64 // packets the browser asked to have resent
+	PLIs         uint64 // keyframes the browser asked for
+	PacketsLost  uint64
+	FractionLost float64       // worst of the streams, over the browser's last report
+	Jitter       time.Duration // worst of the streams, as last reported
+	RTT          time.Duration // as last measured from a receiver report
+	Bandwidth    uint64        // bits per second the browser estimates it can receive, 0 until it sends an estimate
+}
+
+func newStatsInterceptor() *statsInterceptor {
+	return &statsInterceptor{
+		mu:      &sync.Mutex{},
+		streams: make(map[uint32](*streamReport)),
+	}
+}
+
+func (i *statsInterceptor) BindLocalStream(info *interceptor.StreamInfo, writer interceptor.RTPWriter) interceptor.RTPWriter {
+
+	i.mu.Lock()
+	i.streams[info.SSRC] = &streamReport{clockRate: info.ClockRate}
+	i.mu.Unlock()
+
+	return interceptor.RTPWriterFunc(func(header *rtp.Header, payload []byte, attributes interceptor.Attributes) (int, error) {
+		i.mu.Lock()
+		i.packetsSent++
+		i.mu.Unlock()
+
+		return writer.Write(header, payload, attributes)
+	})
+}
+
+func (i *statsInterceptor) UnbindLocalStream(info *interceptor.StreamInfo) {
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.streams, info.SSRC)
+}
+
+func (i *statsInterceptor) BindRTCPReader(reader interceptor.RTCPReader) interceptor.RTCPReader {
+	return interceptor.RTCPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+
+		n, attr, err := reader.Read(b, a)
+		if err != nil {
+			return n, attr, err
+		}
+
+		if pkts, err := rtcp.Unmarshal(b[:n]); err == nil {
+			i.readRTCP(pkts, time.Now())
+		}
+
+		return n, attr, nil
+	})
+}
+
+func (i *statsInterceptor) readRTCP(pkts []rtcp.Packet, now time.Time) {
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, pkt := range pkts {
+		switch p := pkt.(type) {
+		case *rtcp.TransportLayerNack:
+			for _, nack := range p.Nacks {
+				i.nacks += uint64(len(nack.PacketList()))
+			}
+		case *rtcp.PictureLossIndication:
+			i.plis++
+		case *rtcp.ReceiverEstimatedMaximumBitrate:
+			i.remb = p.Bitrate
+		case *rtcp.ReceiverReport:
+			i.readReports(p.Reports, now)
+		case *rtcp.SenderReport:
+			i.readReports(p.Reports, now)
+		}
+	}
+}
+
+// Must hold i.mu
+func (i *statsInterceptor) readReports(reports []rtcp.ReceptionReport, now time.Time) {
+	for _, report := range reports {
+
+		stream, prs := i.streams[report.SSRC]
+		if !prs {
+			continue // not one of ours
+		}
+
+		stream.lost = report.TotalLost
+		stream.fractionLost = float64(report.FractionLost) / 256
+		if stream.clockRate != 0 {
+			stream.jitter = time.Duration(report.Jitter) * time.Second / time.Duration(stream.clockRate)
+		}
+
+		// The round trip is the time since our sender report, less how long the browser held on to it,
+		// both in 1/65536 of a second
+		if report.LastSenderReport != 0 {
+			rtt := ntpMiddle(now) - report.LastSenderReport - report.Delay
+			i.rtt = time.Duration(rtt) * time.Second / 65536
+		}
+	}
+}
+
+func (i *statsInterceptor) stats() RTPStats {
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	stats := RTPStats{
+		PacketsSent: i.packetsSent,
+		NACKs:       i.nacks,
+		PLIs:        i.plis,
+		RTT:         i.rtt,
+		Bandwidth:   i.remb,
+	}
+	for _, stream := range i.streams {
+		stats.PacketsLost += uint64(stream.lost)
+		if stream.fractionLost > stats.FractionLost {
+			stats.FractionLost = stream.fractionLost
+		}
+		if stream.jitter > stats.Jitter {
+			stats.Jitter = stream.jitter
+		}
+	}
+
+	return stats
+}
+
+// The middle 32 bits of an NTP timestamp, as used by receiver reports
+func ntpMiddle(t time.Time) uint32 {
+	return uint32(zutils.ToNTP(t) >> 16)
+}
@@ -0,0 +1,113 @@
+package webrtc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "zoomgaming/proto"
+	zws "zoomgaming/websocket"
+)
+
+/**
+
+Helpers for the signaling protocol spoken across the WebSocket connection.
+
+Every message in either direction is a pb.SignalingEvent
+
+Clients from before SignalingEvent send their offer as a bare pb.SessionDescription, and only understand
+a bare answer. They are still accepted, and are sent nothing but their answer.
+
+*/
+
+// An error that should be reported to the browser before the signaling connection is closed
+type SignalingError struct {
+	Code   pb.SignalingError_Code
+	Reason string
+}
+
+func NewSignalingError(code pb.SignalingError_Code, format string, a ...interface{}) *SignalingError {
+	return &SignalingError{
+		Code:   code,
+		Reason: fmt.Sprintf(format, a...),
+	}
+}
+
+func (e *SignalingError) Error() string {
+	return e.Reason
+}
+
+// Report an error to the browser before an rtc connection exists
+func SendError(ws zws.WebSocket, err error) error {
+	return sendSignal(ws, ErrorEvent(err))
+}
+
+// Wrap an error in a signaling message
+//
+// Errors that are not a *SignalingError are sent with an unspecified code
+func ErrorEvent(err error) *pb.SignalingEvent {
+
+	var serr *SignalingError
+	if !errors.As(err, &serr) {
+		serr = NewSignalingError(pb.SignalingError_CODE_UNSPECIFIED, "%s", err)
+	}
+
+	return &pb.SignalingEvent{
+		Event: &pb.SignalingEvent_Error{
+			Error: &pb.SignalingError{
+				Code:   serr.Code,
+				Reason: serr.Reason,
+			},
+		},
+	}
+}
+
+// Wrap and send a message across the signaling connection
+func sendSignal(ws zws.WebSocket, evt *pb.SignalingEvent) error {
+
+	b, err := proto.Marshal(evt)
+	if err != nil {
+		return err
+	}
+
+	return ws.Send(b)
+}
+
+// A message received across the signaling connection, and whether it came from a client that sends bare session descriptions
+func parseSignal(b []byte) (*pb.SignalingEvent, bool, error) {
+
+	evt := &pb.SignalingEvent{}
+	err := proto.Unmarshal(b, evt)
+	if err == nil && evt.GetEvent() != nil {
+		return evt, false, nil
+	}
+
+	// A bare session description does not parse as a SignalingEvent, its sdp is not a valid SignalingError
+	sd := &pb.SessionDescription{}
+	if proto.Unmarshal(b, sd) == nil && sd.GetType() == pb.SessionDescription_SDP_TYPE_OFFER && strings.HasPrefix(sd.GetSdp(), "v=") {
+		return &pb.SignalingEvent{Event: &pb.SignalingEvent_SessionDescription{SessionDescription: sd}}, true, nil
+	}
+
+	if err == nil {
+		err = errors.New("empty signaling event")
+	}
+	return nil, false, err
+}
+
+// Send a message to a client that only understands bare session descriptions, dropping everything else
+func sendLegacySignal(ws zws.WebSocket, evt *pb.SignalingEvent) error {
+
+	sd := evt.GetSessionDescription()
+	if sd == nil {
+		return nil
+	}
+
+	b, err := proto.Marshal(sd)
+	if err != nil {
+		return err
+	}
+
+	return ws.Send(b)
+}
@@ -0,0 +1,60 @@
+package webrtc
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "zoomgaming/proto"
+)
+
+const testOffer = "v=0\r\no=- 0 0 IN IP4 127.0.0.1\r\ns=-\r\nt=0 0\r\n"
+
+func TestParseSignal(t *testing.T) {
+
+	offer := &pb.SessionDescription{Type: pb.SessionDescription_SDP_TYPE_OFFER, Sdp: testOffer}
+
+	wrapped, err := proto.Marshal(&pb.SignalingEvent{Event: &pb.SignalingEvent_SessionDescription{SessionDescription: offer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bare, err := proto.Marshal(offer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	control, err := proto.Marshal(&pb.SignalingEvent{Event: &pb.SignalingEvent_RoomControl{RoomControl: &pb.RoomControl{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		msg    []byte
+		legacy bool
+	}{
+		{"wrapped offer", wrapped, false},
+		{"bare offer", bare, true},
+		{"room control", control, false},
+	}
+
+	for _, test := range tests {
+		evt, legacy, err := parseSignal(test.msg)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if legacy != test.legacy {
+			t.Errorf("%s: legacy %t, want %t", test.name, legacy, test.legacy)
+		}
+		if sd := evt.GetSessionDescription(); sd != nil && sd.GetSdp() != testOffer {
+			t.Errorf("%s: sdp %q, want %q", test.name, sd.GetSdp(), testOffer)
+		}
+	}
+
+	if _, _, err := parseSignal(nil); err == nil {
+		t.Error("empty message: want an error")
+	}
+	if _, _, err := parseSignal([]byte("not protobuf")); err == nil {
+		t.Error("garbage: want an error")
+	}
+}
@@ -0,0 +1,97 @@
+package webrtc
+
+import (
+	"time"
+
+	"github.com/pion/webrtc/v3"
+)
+
+const statsInterval = 2 * time.Second // how often the stats of a peer connection are collected
+
+// A snapshot of a peer connection
+type ConnectionStats struct {
+	PeerID        string // the rtc connection's id
+	State         string // ICE connection state
+	BytesSent     uint64
+	BytesReceived uint64
+	ICERTT        time.Duration // round trip of the nominated candidate pair, 0 until ICE measures one
+	CandidateType string        // of the nominated candidate pair: host, srflx, prflx or relay
+	RTPStats
+}
+
+// The stats as last collected
+func (w *webRTC) Stats() ConnectionStats {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.stats
+}
+
+func (w *webRTC) setStats(stats ConnectionStats) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stats.PeerID = w.ID()
+	w.stats = stats
+}
+
+// Collect the stats of a peer connection until it closes
+func collectStats(conn *webrtc.PeerConnection, rtp *statsInterceptor, closed <-chan struct{}, set func(ConnectionStats)) {
+
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+		}
+
+		stats := ConnectionStats{
+			State:    conn.ICEConnectionState().String(),
+			RTPStats: rtp.stats(),
+		}
+
+		report := conn.GetStats()
+		for _, s := range report {
+			switch s := s.(type) {
+			case webrtc.TransportStats:
+				stats.BytesSent += s.BytesSent
+				stats.BytesReceived += s.BytesReceived
+			case webrtc.ICECandidatePairStats:
+				if s.Nominated {
+					stats.ICERTT = time.Duration(s.CurrentRoundTripTime * float64(time.Second))
+					stats.CandidateType = candidatePairType(report, s)
+				}
+			}
+		}
+
+		set(stats)
+	}
+}
+
+// The most indirect of the pair's two candidates, e.g. relay if either side is relayed
+func candidatePairType(report webrtc.StatsReport, pair webrtc.ICECandidatePairStats) string {
+
+	rank := map[webrtc.ICECandidateType](int){
+		webrtc.ICECandidateTypeHost:  1,
+		webrtc.ICECandidateTypeSrflx: 2,
+		webrtc.ICECandidateTypePrflx: 3,
+		webrtc.ICECandidateTypeRelay: 4,
+	}
+
+	var typ webrtc.ICECandidateType
+	for _, id := range []string{pair.LocalCandidateID, pair.RemoteCandidateID} {
+		if candidate, ok := report[id].(webrtc.ICECandidateStats); ok && rank[candidate.CandidateType] > rank[typ] {
+			typ = candidate.CandidateType
+		}
+	}
+
+	if typ == webrtc.ICECandidateType(webrtc.Unknown) {
+		return ""
+	}
+	return typ.String()
+}
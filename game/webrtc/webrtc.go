@@ -4,16 +4,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pion/interceptor"
 	"github.com/pion/webrtc/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"zoomgaming/logging"
 	pb "zoomgaming/proto"
 	zutils "zoomgaming/utils"
 	zws "zoomgaming/websocket"
@@ -29,9 +33,13 @@ The local session description is offered only once.
 // Created data Channels and supported message types
 // Data channels ARE negotiated in advance - make sure to create them in browser.
 GameInput: pb.GameInput
+RoomState: pb.RoomEvent
+ChatRoom: pb.ChatMessage
+Latency: pb.LatencyProbe
 
 // Media Tracks
-None
+Sent: the game's video and audio, one voice track per seat, then the commentary mix if the room has one, in that order
+Received: the browser's microphone, optional
 
 Unable to implement Perfect negotiation w/ "impolite" peer
 - Pion/webrtc does not support rollback as an SDPType
@@ -40,30 +48,50 @@ Unable to implement Perfect negotiation w/ "impolite" peer
 */
 
 type WebRTC interface {
-	DataChannels() chan (<-chan proto.Message)
-	// Broadcast() chan (<-chan *webrtc.TrackLocalStaticRTP)
-	// AddTrack(*webrtc.TrackLocalStaticRTP)
-	Send(proto.Message) error // send a message to the client
-	Close() error             // close the connection
+	ID() string             // uniquely identifies this connection
+	Stats() ConnectionStats // a snapshot of the connection's state and traffic
+	DataChannels() chan (DataChannelUpdate)
+	Controls() <-chan *pb.RoomControl       // room controls sent across the signaling connection
+	VoiceControls() <-chan *pb.VoiceControl // voice controls sent across the signaling connection
+	Broadcast() chan (<-chan []byte)        // the browser's microphone, as rtp packets
+	Send(proto.Message) error               // send a message to the client
+	Signal(*pb.SignalingEvent) error        // send a message to the client across the signaling connection
+	Close() error                           // close the connection
+}
+
+// A data channel that has opened, and the messages the browser sends on it
+type DataChannelUpdate struct {
+	Label    DataChannelLabel
+	Messages <-chan proto.Message
 }
 
 // The server in a client-server connection between two webrtc agents
 type webRTC struct {
-	conn       *webrtc.PeerConnection
-	videoTrack *webrtc.TrackLocalStaticRTP
-	audioTrack *webrtc.TrackLocalStaticRTP
-	id         uuid.UUID // identifier to distinguish this connection from others
-
-	ws      zws.WebSocket               // WebSocket connection used for signaling
-	updates chan (<-chan proto.Message) // notify the listener of any new data chhanels
-	// trackUpdates      chan (<-chan *webrtc.TrackLocalStaticRTP) // notify the listener of any new media tracks from the browser
+	conn            *webrtc.PeerConnection
+	videoTrack      *webrtc.TrackLocalStaticRTP
+	audioTrack      *webrtc.TrackLocalStaticRTP
+	voiceTracks     []*webrtc.TrackLocalStaticRTP // other players' voices, one track per seat
+	commentaryTrack *webrtc.TrackLocalStaticRTP   // the game's audio mixed with voice chat, may be nil
+	answerTimes     prometheus.Observer           // seconds from each offer to its answer, may be nil
+	id              uuid.UUID                     // identifier to distinguish this connection from others
+
+	ws                zws.WebSocket                      // WebSocket connection used for signaling
+	updates           chan (DataChannelUpdate)           // notify the listener of any new data chhanels
+	controls          chan *pb.RoomControl               // room controls received from the browser
+	voiceControls     chan *pb.VoiceControl              // voice controls received from the browser
+	broadcasts        chan (<-chan []byte)               // notify the listener of any new media tracks from the browser
 	dataChannels      map[DataChannelLabel](DataChannel) // use this mapping to send messages to the browser
-	mu                *sync.Mutex                        // protects candidates
+	mu                *sync.Mutex                        // protects candidates, conn and dataChannels
 	pendingCandidates []*webrtc.ICECandidate             // save candidates for after the browser answers
+	stats             ConnectionStats                    // as last collected, protected by mu
+	closed            chan struct{}                      // closed, under mu, once the connection is being torn down
+	tracks            *sync.WaitGroup                    // track handlers handing a microphone over on broadcasts
+	legacy            int32                              // 1 if the client sent a bare session description, accessed atomically
+	log               *zap.Logger                        // with the connection's id
 }
 
 // Constructor
-func NewWebRTC(ws zws.WebSocket, videoTrack *webrtc.TrackLocalStaticRTP, audioTrack *webrtc.TrackLocalStaticRTP) (WebRTC WebRTC, err error) {
+func NewWebRTC(ws zws.WebSocket, videoTrack *webrtc.TrackLocalStaticRTP, audioTrack *webrtc.TrackLocalStaticRTP, voiceTracks []*webrtc.TrackLocalStaticRTP, commentaryTrack *webrtc.TrackLocalStaticRTP, answerTimes prometheus.Observer, log *zap.Logger) (WebRTC WebRTC, err error) {
 
 	// Catch any panics and return (nil, err) after recovering from panic
 	defer func() {
@@ -72,17 +100,27 @@ func NewWebRTC(ws zws.WebSocket, videoTrack *webrtc.TrackLocalStaticRTP, audioTr
 		}
 	}()
 
+	id := uuid.New()
 	w := &webRTC{
-		ws:         ws,
-		videoTrack: videoTrack,
-		audioTrack: audioTrack,
-		id:         uuid.New(),
-		updates:    make(chan (<-chan proto.Message)),
-		// trackUpdates:      make(chan (<-chan *webrtc.TrackLocalStaticRTP)),
+		ws:                ws,
+		videoTrack:        videoTrack,
+		audioTrack:        audioTrack,
+		voiceTracks:       voiceTracks,
+		commentaryTrack:   commentaryTrack,
+		answerTimes:       answerTimes,
+		id:                id,
+		updates:           make(chan (DataChannelUpdate)),
+		controls:          make(chan *pb.RoomControl, 16),
+		voiceControls:     make(chan *pb.VoiceControl, 16),
+		broadcasts:        make(chan (<-chan []byte)),
 		dataChannels:      make(map[DataChannelLabel](DataChannel)),
 		mu:                &sync.Mutex{},
 		pendingCandidates: make([]*webrtc.ICECandidate, 0),
+		closed:            make(chan struct{}),
+		tracks:            &sync.WaitGroup{},
+		log:               log.With(logging.Peer(id.String())),
 	}
+	w.stats.PeerID = w.ID()
 
 	// go routine to handle received websocket messages
 	// also tears down RTCPeerConnection on death of websocket connection
@@ -92,16 +130,30 @@ func NewWebRTC(ws zws.WebSocket, videoTrack *webrtc.TrackLocalStaticRTP, audioTr
 	return
 }
 
+func (w *webRTC) ID() string {
+	return w.id.String()
+}
+
 // Incoming datachannel... channels, and messages from those channels
-func (w *webRTC) DataChannels() chan (<-chan proto.Message) {
+func (w *webRTC) DataChannels() chan (DataChannelUpdate) {
 	return w.updates
 }
 
-/**
-func (w *webRTC) Broadcast() chan (<-chan *webrtc.TrackLocalStaticRTP) {
-	return w.trackUpdates
+// Room controls are handled by the room, not the rtc connection
+func (w *webRTC) Controls() <-chan *pb.RoomControl {
+	return w.controls
 }
-*/
+
+// Voice controls are handled by the room, not the rtc connection
+func (w *webRTC) VoiceControls() <-chan *pb.VoiceControl {
+	return w.voiceControls
+}
+
+// Incoming media tracks, and rtp packets from those tracks
+func (w *webRTC) Broadcast() chan (<-chan []byte) {
+	return w.broadcasts
+}
+
 // Send a message to the browser idiomatically based on message type
 func (w *webRTC) Send(msg proto.Message) error {
 
@@ -110,7 +162,9 @@ func (w *webRTC) Send(msg proto.Message) error {
 		return errors.New("Invalid message type")
 	}
 
+	w.mu.Lock()
 	dc, prs := w.dataChannels[label]
+	w.mu.Unlock()
 	if !prs {
 		return errors.New(fmt.Sprintf("Data Channel with label %s not found", label))
 	}
@@ -118,6 +172,13 @@ func (w *webRTC) Send(msg proto.Message) error {
 	return dc.Send(msg)
 }
 
+func (w *webRTC) Signal(evt *pb.SignalingEvent) error {
+	if atomic.LoadInt32(&w.legacy) == 1 {
+		return sendLegacySignal(w.ws, evt)
+	}
+	return sendSignal(w.ws, evt)
+}
+
 func (w *webRTC) Close() error {
 	return w.ws.Close() // close the websocket connection
 }
@@ -127,6 +188,8 @@ func (w *webRTC) Close() error {
 // Expected Events:
 //	pb.SessionDescription
 //	pb.RtcIceCandidateInit
+//	pb.RoomControl
+//	pb.VoiceControl
 //
 // Ignored Events:
 //	pb.RtcIceServer
@@ -134,14 +197,25 @@ func (w *webRTC) watchWebSocket() {
 
 	defer func() {
 		// Start the teardown sequence and close all data channels
+		w.mu.Lock()
 		for _, dc := range w.dataChannels {
 			dc.Close()
 		}
-		log.Println("ws closing...")
-		close(w.updates)
-		if w.conn != nil {
-			w.conn.Close()
+		conn := w.conn
+		close(w.closed)
+		w.mu.Unlock()
+		w.log.Debug("WebSocket closed, tearing down the connection")
+
+		// No track may arrive once the peer connection is closed, and those that did give up on w.closed,
+		// so nothing is sent on the channels once they are closed
+		if conn != nil {
+			conn.Close()
 		}
+		w.tracks.Wait()
+		close(w.updates)
+		close(w.controls)
+		close(w.voiceControls)
+		close(w.broadcasts)
 	}()
 
 	wsOpen := w.ws.Updates()
@@ -153,13 +227,25 @@ func (w *webRTC) watchWebSocket() {
 				return
 			}
 			for b := range ch {
-				var msg pb.SessionDescription
-				if err := proto.Unmarshal(b, msg.ProtoReflect().Interface()); err != nil {
-					log.Printf("Error unmarshaling message: %v", b)
+				msg, legacy, err := parseSignal(b)
+				if err != nil {
+					w.log.Warn("Error unmarshaling signaling message", zap.Error(err))
 					continue
-				} else {
-					err := w.handleSessionDescription(&msg)
-					zutils.WarnOnError(err, "Error handling client offer: %s")
+				}
+				if legacy {
+					w.log.Debug("Client sent a bare session description")
+					atomic.StoreInt32(&w.legacy, 1)
+				}
+				switch evt := msg.GetEvent().(type) {
+				case *pb.SignalingEvent_SessionDescription:
+					err := w.handleSessionDescription(evt.SessionDescription)
+					zutils.WarnOnError(w.log, err, "Error handling client offer")
+				case *pb.SignalingEvent_RoomControl:
+					w.controls <- evt.RoomControl
+				case *pb.SignalingEvent_VoiceControl:
+					w.voiceControls <- evt.VoiceControl
+				default:
+					w.log.Warn("Unexpected signaling event", zap.String("type", fmt.Sprintf("%T", evt)))
 				}
 			}
 		}
@@ -175,78 +261,23 @@ func (w *webRTC) handleSessionDescription(msg *pb.SessionDescription) error {
 		return errors.New("expected an offer")
 	}
 	offerStr := msg.GetSdp()
-	// log.Println(offerStr)
+	offered := time.Now()
+	w.log.Debug("Received offer", zap.String("sdp", offerStr))
 
-	// Create a MediaEngine object to configure the supported codec
-	m := &webrtc.MediaEngine{}
-
-	videoRTCPFeedback := []webrtc.RTCPFeedback{{"goog-remb", ""}, {"ccm", "fir"}, {"nack", ""}, {"nack", "pli"}}
-	/**
-	videoRTPCodecParameters := []webrtc.RTPCodecParameters{
-		{RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback}, PayloadType: 96},
-	}
-	*/
-	// Setup the codecs you want to use.
-	// We'll use a VP8 and Opus but you can also define your own
-	videoRTPCodecParameters := []webrtc.RTPCodecParameters{
-		{RTPCodecCapability: webrtc.RTPCodecCapability{
-			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
-			//SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=1;profile-level-id=42001f",
-		}, PayloadType: 102},
-		{RTPCodecCapability: webrtc.RTPCodecCapability{
-			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
-			SDPFmtpLine: "level-asymmetry-allowed=1;profile-level-id=42e01f",
-		}, PayloadType: 108},
-		{RTPCodecCapability: webrtc.RTPCodecCapability{
-			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
-		}, PayloadType: 123},
-		{RTPCodecCapability: webrtc.RTPCodecCapability{
-			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
-			SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=1;profile-level-id=42e01f",
-		}, PayloadType: 125},
-		{RTPCodecCapability: webrtc.RTPCodecCapability{
-			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
-			SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=0;profile-level-id=42001f",
-		}, PayloadType: 127},
-	}
-
-	for _, codec := range videoRTPCodecParameters {
-		if err := m.RegisterCodec(codec, webrtc.RTPCodecTypeVideo); err != nil {
-			return err
-		}
-	}
-
-	if err := m.RegisterCodec(webrtc.RTPCodecParameters{
-		RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus},
-		PayloadType:        96,
-	}, webrtc.RTPCodecTypeAudio); err != nil {
-		return err
-	}
-
-	// Create a InterceptorRegistry. This is the user configurable RTP/RTCP Pipeline.
-	// This provides NACKs, RTCP Reports and other features. If you use `webrtc.NewPeerConnection`
-	// this is enabled by default. If you are manually managing You MUST create a InterceptorRegistry
-	// for each PeerConnection.
-	i := &interceptor.Registry{}
-
-	// Use the default set of Interceptors
-	if err := webrtc.RegisterDefaultInterceptors(m, i); err != nil {
+	api, rtpStats, err := newAPI()
+	if err != nil {
 		return err
 	}
 
-	// Create a setting engine. This allows influencing behavior in ways that are not support by the WebRTC API.
-	e := &webrtc.SettingEngine{}
-
-	e.SetEphemeralUDPPortRange(30000, 40000)
-
-	// Create the API object with the MediaEngine
-	api := webrtc.NewAPI(webrtc.WithMediaEngine(m), webrtc.WithInterceptorRegistry(i), webrtc.WithSettingEngine(*e))
-
 	conn, err := api.NewPeerConnection(defaultRTCConfiguration)
 	if err != nil {
 		return err
 	}
+	w.mu.Lock()
 	w.conn = conn
+	w.mu.Unlock()
+
+	go collectStats(conn, rtpStats, w.closed, w.setStats)
 	/**
 	_, err = conn.AddTrack(w.videoTrack)
 	if err != nil {
@@ -292,22 +323,54 @@ func (w *webRTC) handleSessionDescription(msg *pb.SessionDescription) error {
 		}
 	}()
 
-	// WebRTC Data Channel - GameInput
-	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-	input_impl, err := w.conn.CreateDataChannel(GameInput.String(), dcConfigs[GameInput])
-	if err != nil {
-		return err
+	tracks := w.voiceTracks
+	if w.commentaryTrack != nil {
+		tracks = append(tracks[:len(tracks):len(tracks)], w.commentaryTrack)
 	}
 
-	input := NewDataChannel(GameInput, input_impl)
-	w.dataChannels[GameInput] = input
+	for _, track := range tracks {
+		sender, err := conn.AddTrack(track)
+		if err != nil {
+			return err
+		}
 
-	go func() {
-		updates := input.Updates()
-		for ch := range updates {
-			w.updates <- ch
+		go func() {
+			rtcpBuf := make([]byte, 1500)
+			for {
+				if _, _, rtcpErr := sender.Read(rtcpBuf); rtcpErr != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	// The browser's microphone, if it sends one
+	if _, err := conn.AddTransceiverFromKind(webrtc.RTPCodecTypeAudio, webrtc.RTPTransceiverInit{
+		Direction: webrtc.RTPTransceiverDirectionRecvonly,
+	}); err != nil {
+		return err
+	}
+
+	// WebRTC Data Channels - GameInput, RoomState, ChatRoom, Latency
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+	for _, label := range []DataChannelLabel{GameInput, RoomState, ChatRoom, Latency} {
+		dc_impl, err := w.conn.CreateDataChannel(label.String(), dcConfigs[label])
+		if err != nil {
+			return err
 		}
-	}()
+
+		dc := NewDataChannel(label, dc_impl, w.log)
+		w.mu.Lock()
+		w.dataChannels[label] = dc
+		w.mu.Unlock()
+
+		go func(label DataChannelLabel) {
+			updates := dc.Updates()
+			for ch := range updates {
+				w.updates <- DataChannelUpdate{Label: label, Messages: ch}
+			}
+		}(label)
+	}
 
 	if err := w.conn.SetRemoteDescription(webrtc.SessionDescription{
 		Type: webrtc.SDPTypeOffer,
@@ -324,94 +387,160 @@ func (w *webRTC) handleSessionDescription(msg *pb.SessionDescription) error {
 	w.conn.SetLocalDescription(answer)
 
 	w.conn.OnICECandidate(func(c *webrtc.ICECandidate) {
-		// log.Println("OnIceCandidate:", c)
+		w.log.Debug("Gathered ICE candidate", zap.Stringer("candidate", c))
 		if c == nil {
 
 			local_sdp := w.conn.LocalDescription()
 
-			// log.Println(local_sdp)
+			w.log.Debug("Sending answer", zap.String("sdp", local_sdp.SDP))
 
 			sdp := pb.SessionDescription{}
 
 			// pion/webrtc struct in json format
 			temp_b, err := json.Marshal(local_sdp)
 			if err != nil {
-				log.Printf("%s", err)
+				w.log.Warn("Error marshalling answer", zap.Error(err))
 				return
 			}
 
 			if err := protojson.Unmarshal(temp_b, sdp.ProtoReflect().Interface()); err != nil {
-				log.Printf("%s", err)
+				w.log.Warn("Error converting answer", zap.Error(err))
 				return
 			}
 
-			b_arr, err := proto.Marshal(sdp.ProtoReflect().Interface())
-			if err != nil {
-				log.Printf("%s", err)
-				return
+			err = w.Signal(&pb.SignalingEvent{
+				Event: &pb.SignalingEvent_SessionDescription{SessionDescription: &sdp},
+			})
+			zutils.WarnOnError(w.log, err, "Error sending answer to browser client")
+			if err == nil && w.answerTimes != nil {
+				w.answerTimes.Observe(time.Since(offered).Seconds())
 			}
-
-			err = w.ws.Send(b_arr)
-			zutils.WarnOnError(err, "Error sending sdp to browser client: %s")
 		}
 	})
 
 	w.conn.OnICEConnectionStateChange(func(connectionState webrtc.ICEConnectionState) {
-		// log.Printf("ICE Connection State has changed: %s", connectionState.String())
-		if connectionState == webrtc.ICEConnectionStateConnected {
-			// log.Println("ConnectionStateConnected")
-		}
+		w.log.Debug("ICE connection state changed", zap.Stringer("state", connectionState))
 		if connectionState == webrtc.ICEConnectionStateFailed || connectionState == webrtc.ICEConnectionStateClosed || connectionState == webrtc.ICEConnectionStateDisconnected {
 			w.Close()
 		}
 	})
 
-	/**
-	w.conn.OnICEGatheringStateChange(func(s webrtc.ICEGathererState) {
-		log.Println("ICE Gatherer State: ", s.String())
-	})
 	w.conn.OnTrack(func(remoteTrack *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
-		// Create a local track, all our SFU clients will be fed via this track
-		localTrack, newTrackErr := webrtc.NewTrackLocalStaticRTP(
-			remoteTrack.Codec().RTPCodecCapability,
-			fmt.Sprintf("%s-%s", remoteTrack.Kind().String(), w.id.String()),
-			"PlayerStream",
-		)
-		if newTrackErr != nil {
-			log.Printf("Error creating local track: %s", newTrackErr)
+		if remoteTrack.Kind() != webrtc.RTPCodecTypeAudio {
+			w.log.Info("Ignoring track from the browser", zap.Stringer("kind", remoteTrack.Kind()))
 			return
 		}
 
-		// Send a PLI on an interval so that the publisher is pushing a keyframe every rtcpPLIInterval
-		// This can be less wasteful by processing incoming RTCP events, then we would emit a NACK/PLI when a viewer requests it
-		go func() {
-			ticker := time.NewTicker(rtcpPLIInterval)
-			for range ticker.C {
-				if rtcpSendErr := peerConnection.WriteRTCP([]rtcp.Packet{&rtcp.PictureLossIndication{MediaSSRC: uint32(remoteTrack.SSRC())}}); rtcpSendErr != nil {
-					fmt.Println(rtcpSendErr)
-				}
-			}
-		}()
+		w.mu.Lock()
+		select {
+		case <-w.closed:
+			w.mu.Unlock()
+			return
+		default:
+		}
+		w.tracks.Add(1)
+		w.mu.Unlock()
 
-		w.trackUpdates <- localTrack
+		ch := make(chan []byte, 256)
+		select {
+		case w.broadcasts <- ch:
+			w.tracks.Done()
+		case <-w.closed:
+			w.tracks.Done()
+			return
+		}
 
 		go func() {
-			rtpBuf := make([]byte, 1600)
+			defer close(ch)
 			for {
+				rtpBuf := make([]byte, 1500)
 				i, _, readErr := remoteTrack.Read(rtpBuf)
 				if readErr != nil {
-					log.Printf("read error: %s", err)
 					return
 				}
 
-				// ErrClosedPipe means we don't have any subscribers, this is ok if no peers have connected yet
-				if _, err = localTrack.Write(rtpBuf[:i]); err != nil && !errors.Is(err, io.ErrClosedPipe) {
-					log.Printf("write error: %s", err)
-					return
+				select {
+				case ch <- rtpBuf[:i]:
+				default: // drop packets rather than stall the track if the room falls behind
 				}
 			}
 		}()
 	})
-	*/
+
 	return nil
 }
+
+// The codecs, interceptors and settings of the server's peer connections, and an interceptor counting their rtp
+func newAPI() (*webrtc.API, *statsInterceptor, error) {
+
+	// Create a MediaEngine object to configure the supported codec
+	m := &webrtc.MediaEngine{}
+
+	videoRTCPFeedback := []webrtc.RTCPFeedback{{Type: "goog-remb"}, {Type: "ccm", Parameter: "fir"}, {Type: "nack"}, {Type: "nack", Parameter: "pli"}}
+	/**
+	videoRTPCodecParameters := []webrtc.RTPCodecParameters{
+		{RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback}, PayloadType: 96},
+	}
+	*/
+	// Setup the codecs you want to use.
+	// We'll use a VP8 and Opus but you can also define your own
+	videoRTPCodecParameters := []webrtc.RTPCodecParameters{
+		{RTPCodecCapability: webrtc.RTPCodecCapability{
+			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
+			//SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=1;profile-level-id=42001f",
+		}, PayloadType: 102},
+		{RTPCodecCapability: webrtc.RTPCodecCapability{
+			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
+			SDPFmtpLine: "level-asymmetry-allowed=1;profile-level-id=42e01f",
+		}, PayloadType: 108},
+		{RTPCodecCapability: webrtc.RTPCodecCapability{
+			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
+		}, PayloadType: 123},
+		{RTPCodecCapability: webrtc.RTPCodecCapability{
+			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
+			SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=1;profile-level-id=42e01f",
+		}, PayloadType: 125},
+		{RTPCodecCapability: webrtc.RTPCodecCapability{
+			MimeType: webrtc.MimeTypeH264, ClockRate: 90000, RTCPFeedback: videoRTCPFeedback,
+			SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=0;profile-level-id=42001f",
+		}, PayloadType: 127},
+	}
+
+	for _, codec := range videoRTPCodecParameters {
+		if err := m.RegisterCodec(codec, webrtc.RTPCodecTypeVideo); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := m.RegisterCodec(webrtc.RTPCodecParameters{
+		RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus},
+		PayloadType:        96,
+	}, webrtc.RTPCodecTypeAudio); err != nil {
+		return nil, nil, err
+	}
+
+	// Create a InterceptorRegistry. This is the user configurable RTP/RTCP Pipeline.
+	// This provides NACKs, RTCP Reports and other features. If you use `webrtc.NewPeerConnection`
+	// this is enabled by default. If you are manually managing You MUST create a InterceptorRegistry
+	// for each PeerConnection.
+	i := &interceptor.Registry{}
+
+	// Use the default set of Interceptors
+	if err := webrtc.RegisterDefaultInterceptors(m, i); err != nil {
+		return nil, nil, err
+	}
+
+	// Count what is sent to the browser, and what it reports back, for Stats
+	rtpStats := newStatsInterceptor()
+	i.Add(rtpStats)
+
+	// Create a setting engine. This allows influencing behavior in ways that are not support by the WebRTC API.
+	e := &webrtc.SettingEngine{}
+
+	e.SetEphemeralUDPPortRange(30000, 40000)
+
+	// Create the API object with the MediaEngine
+	api := webrtc.NewAPI(webrtc.WithMediaEngine(m), webrtc.WithInterceptorRegistry(i), webrtc.WithSettingEngine(*e))
+
+	return api, rtpStats, nil
+}
@@ -0,0 +1,178 @@
+package webrtc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pion/webrtc/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"zoomgaming/logging"
+)
+
+/**
+
+A viewer that watches the game's video and audio over WHEP, the WebRTC-HTTP Egress Protocol,
+so that OBS and off-the-shelf players can watch a room without speaking our signaling
+
+The offer is posted over HTTP and answered in the response, with every ICE candidate gathered,
+since the connection has no signaling channel to trickle candidates on. Viewers only receive media,
+they have no data channels, voice chat or commentary.
+
+*/
+
+const gatherTimeout = 10 * time.Second
+
+type WHEP interface {
+	ID() string             // uniquely identifies this connection, and names its WHEP resource
+	Stats() ConnectionStats // a snapshot of the connection's state and traffic
+	Answer() string         // the sdp answering the viewer's offer
+	Done() <-chan struct{}  // closed once the connection is torn down
+	Close() error
+}
+
+type whep struct {
+	conn   *webrtc.PeerConnection
+	id     uuid.UUID
+	answer string
+	mu     *sync.Mutex     // protects stats
+	stats  ConnectionStats // as last collected
+	once   *sync.Once
+	closed chan struct{}
+	log    *zap.Logger
+}
+
+func NewWHEP(offer string, videoTrack *webrtc.TrackLocalStaticRTP, audioTrack *webrtc.TrackLocalStaticRTP, answerTimes prometheus.Observer, log *zap.Logger) (WHEP WHEP, err error) {
+
+	var conn *webrtc.PeerConnection
+
+	// Catch any panics and return (nil, err) after recovering from panic
+	defer func() {
+		if r := recover(); r != nil {
+			if conn != nil {
+				conn.Close()
+			}
+			err = errors.New(fmt.Sprintf("%s", r))
+		}
+	}()
+
+	offered := time.Now()
+	id := uuid.New()
+
+	api, rtpStats, err := newAPI()
+	if err != nil {
+		panic(fmt.Sprintf("Error configuring peer connection: %s", err))
+	}
+
+	conn, err = api.NewPeerConnection(defaultRTCConfiguration)
+	if err != nil {
+		panic(fmt.Sprintf("Error creating peer connection: %s", err))
+	}
+
+	w := &whep{
+		conn:   conn,
+		id:     id,
+		mu:     &sync.Mutex{},
+		once:   &sync.Once{},
+		closed: make(chan struct{}),
+		log:    log.With(logging.Peer(id.String())),
+	}
+	w.stats.PeerID = w.ID()
+
+	conn.OnICEConnectionStateChange(func(state webrtc.ICEConnectionState) {
+		w.log.Debug("ICE connection state changed", zap.Stringer("state", state))
+		if state == webrtc.ICEConnectionStateFailed || state == webrtc.ICEConnectionStateClosed || state == webrtc.ICEConnectionStateDisconnected {
+			go w.Close() // closing the connection fires this handler again
+		}
+	})
+
+	for _, track := range []*webrtc.TrackLocalStaticRTP{videoTrack, audioTrack} {
+		sender, err := conn.AddTrack(track)
+		if err != nil {
+			panic(fmt.Sprintf("Error adding %s track: %s", track.Kind(), err))
+		}
+
+		// Read incoming RTCP packets, for the interceptors to handle NACKs and reports
+		go func() {
+			rtcpBuf := make([]byte, 1500)
+			for {
+				if _, _, rtcpErr := sender.Read(rtcpBuf); rtcpErr != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	if err := conn.SetRemoteDescription(webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: offer}); err != nil {
+		panic(fmt.Sprintf("Error accepting offer: %s", err))
+	}
+
+	answer, err := conn.CreateAnswer(nil)
+	if err != nil {
+		panic(fmt.Sprintf("Error creating answer: %s", err))
+	}
+
+	gathered := webrtc.GatheringCompletePromise(conn)
+	if err := conn.SetLocalDescription(answer); err != nil {
+		panic(fmt.Sprintf("Error setting answer: %s", err))
+	}
+
+	select {
+	case <-gathered:
+	case <-time.After(gatherTimeout):
+		panic("Timed out gathering ICE candidates")
+	}
+	w.answer = conn.LocalDescription().SDP
+
+	go collectStats(conn, rtpStats, w.closed, w.setStats)
+
+	if answerTimes != nil {
+		answerTimes.Observe(time.Since(offered).Seconds())
+	}
+
+	WHEP = w
+	return
+}
+
+func (w *whep) ID() string {
+	return w.id.String()
+}
+
+func (w *whep) Stats() ConnectionStats {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.stats
+}
+
+func (w *whep) setStats(stats ConnectionStats) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stats.PeerID = w.ID()
+	w.stats = stats
+}
+
+func (w *whep) Answer() string {
+	return w.answer
+}
+
+func (w *whep) Done() <-chan struct{} {
+	return w.closed
+}
+
+func (w *whep) Close() error {
+
+	var err error
+	w.once.Do(func() {
+		close(w.closed)
+		err = w.conn.Close()
+	})
+	return err
+}
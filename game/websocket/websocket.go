@@ -1,11 +1,13 @@
 package websocket
 
 import (
-	"log"
 	"sync"
 	"time"
 
 	websocket "github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"zoomgaming/logging"
 )
 
 /**
@@ -60,7 +62,7 @@ func (ws *webSocket) Send(msg []byte) error {
 	w, err := ws.conn.NextWriter(websocket.BinaryMessage)
 	if err != nil {
 		if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-			// log.Printf("Closing Error: %s", err)
+			logging.L().Debug("Unexpected WebSocket close", zap.Error(err))
 		}
 		return err
 	}
@@ -68,7 +70,7 @@ func (ws *webSocket) Send(msg []byte) error {
 	w.Write(msg)
 
 	if err = w.Close(); err != nil {
-		// log.Printf("Error closing message: %s", err)
+		logging.L().Debug("Error closing WebSocket message", zap.Error(err))
 		return err
 	}
 
@@ -93,7 +95,7 @@ func (ws *webSocket) readPump() {
 		ws.conn.Close()
 		close(ws.receiver)
 		close(ws.updates)
-		log.Println("closed ws conn")
+		logging.L().Debug("Closed WebSocket connection")
 	}()
 
 	ws.updates <- ws.receiver
@@ -103,7 +105,7 @@ func (ws *webSocket) readPump() {
 		if err != nil {
 			// Log an error if this websocket connection did not close properly
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-				log.Printf("Closing Error: %s", err)
+				logging.L().Warn("Unexpected WebSocket close", zap.Error(err))
 			}
 			break
 		}
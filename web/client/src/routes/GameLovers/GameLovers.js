@@ -1,6 +1,7 @@
 import React, {useEffect, useState} from "react";
 import { DCLabel } from "./datachannel";
 import { InputMap } from "./input";
+import { Signaling } from "./signaling";
 import Button from "@material-ui/core/Button";
 import {gamecode} from "../TabooGame/src/__fixtures__/game";
 import {useHistory} from "react-router";
@@ -8,7 +9,6 @@ import DialogContent from "@material-ui/core/DialogContent";
 import {DialogActions, Grid, TextField, Typography} from "@material-ui/core";
 import Dialog from "@material-ui/core/Dialog";
 import {Transition, useStyles} from "../CreateRoom";
-const pb = require('./proto/signaling_pb');
 const input = require('./proto/input_pb');
 
 // const SERVER_ADDR = "35.232.40.2";
@@ -34,18 +34,37 @@ const GameLovers = (props) => {
  useEffect(() => {
    let peerConnection = null; // webrtc connection
    let input_dc = null; // keyboard events are sent to the server using this
-   let webSocket = new WebSocket(`wss://${SERVER_ADDR}/demo/${props.roomId}/${props.gameId}`); // session description is sent/received via websocket
-   webSocket.binaryType = "arraybuffer" // blob or arraybuffer
-   // webSocket.addEventListener("open", event => { console.log("ws open"); });
-   webSocket.addEventListener("message", event => { handleWebsocketEvent(event); });
-   webSocket.addEventListener("close", event => { console.log("ws closing"); updateFailed(true) });
-   webSocket.onerror = function(event) { console.error("WebSocket error observed:", event); };
+   let webSocket = null; // session description is sent/received via websocket
+   let closed = false; // the effect was cleaned up, possibly before the token arrived
+
+   // The game server only lets clients with a room token join, which the web server signs for us.
+   // The web server keeps our client id in a cookie across visits, so that a host who reloads is still the host.
+   let fetchToken = () => {
+     let params = new URLSearchParams({ room: props.roomId, game: props.gameId });
+     return fetch("/api/session", { method: "POST", credentials: "same-origin" }).then(res => {
+       if (!res.ok) {
+         throw new Error(`could not start a session: ${res.status}`);
+       }
+       return fetch(`/api/token?${params}`, { credentials: "same-origin" });
+     }).then(res => {
+       if (!res.ok) {
+         throw new Error(`could not get a room token: ${res.status}`);
+       }
+       return res.json();
+     }).then(({ token }) => token);
+   }
+
+   let connect = (token) => {
+     webSocket = new WebSocket(`wss://${SERVER_ADDR}/demo/${props.roomId}/${props.gameId}?token=${encodeURIComponent(token)}`);
+     webSocket.binaryType = "arraybuffer" // blob or arraybuffer
+     webSocket.addEventListener("open", event => { handleWebsocketOpen(event); });
+     webSocket.addEventListener("message", event => { handleWebsocketEvent(event); });
+     webSocket.addEventListener("close", event => { console.log("ws closing"); updateFailed(true) });
+     webSocket.onerror = function(event) { console.error("WebSocket error observed:", event); };
+   }
 
    let startSession = (offer) => {
-     let pb_sd = new pb.SessionDescription();
-     pb_sd.setType(pb.SessionDescription.SDPType.SDP_TYPE_OFFER);
-     pb_sd.setSdp(offer);
-     let uint8_array = pb_sd.serializeBinary();
+     let uint8_array = Signaling.EncodeOffer(offer);
      console.log("sending local offer to the server...");
      webSocket.send(uint8_array.buffer);
    }
@@ -66,7 +85,7 @@ const GameLovers = (props) => {
 
    let handleWebsocketEvent = (event) => {
      if ( Object.getPrototypeOf(event) === MessageEvent.prototype ) {
-       let remote_sdp_answer = new pb.SessionDescription.deserializeBinary(event.data);
+       let remote_sdp_answer = Signaling.DecodeSessionDescription(event.data);
        if (remote_sdp_answer) {
          console.log("received a remote answer from server...");
          peerConnection.setRemoteDescription({
@@ -74,7 +93,7 @@ const GameLovers = (props) => {
            sdp: remote_sdp_answer.getSdp()
          });
        } else {
-         console.error("not an sdp");
+         console.log("ignoring a signaling event that is not an sdp");
        }
      } else {
        console.log(`Received event with prototype of ${Object.getPrototypeOf(event)}`);
@@ -142,7 +161,7 @@ const GameLovers = (props) => {
      }).then(() => pc);
    }
 
-   webSocket.addEventListener("open", event => {
+   let handleWebsocketOpen = (event) => {
      var remoteVideo = document.querySelector('#remote-video');
      if (!peerConnection) {
        console.log("ws open");
@@ -152,7 +171,13 @@ const GameLovers = (props) => {
          peerConnection = pc;
        }).catch((error) => { console.error(error); });
      }
-   });
+   }
+
+   fetchToken().then(token => {
+     if (!closed) {
+       connect(token);
+     }
+   }).catch((error) => { console.error(error); updateFailed(true); });
 
    window.addEventListener('beforeunload', () => {
      if (peerConnection) {
@@ -163,6 +188,7 @@ const GameLovers = (props) => {
      }
    });
    return () => {
+     closed = true;
      if (peerConnection) {
        peerConnection.close();
      }
@@ -0,0 +1,54 @@
+"use strict";
+const jspb = require('google-protobuf');
+const pb = require('./proto/signaling_pb');
+
+/**
+ * Every message on the signaling WebSocket is a SignalingEvent, see proto/signaling.proto.
+ * Only its session_description is read and written here, the other events are skipped.
+ */
+var Signaling = {
+  /**
+   * Field number of session_description in SignalingEvent
+   * @const {number}
+   */
+  SESSION_DESCRIPTION: 1,
+
+  /**
+   * @param {string} sdp
+   * @return {!Uint8Array} a SignalingEvent carrying the offer
+   */
+  EncodeOffer: (sdp) => {
+    let pb_sd = new pb.SessionDescription();
+    pb_sd.setType(pb.SessionDescription.SDPType.SDP_TYPE_OFFER);
+    pb_sd.setSdp(sdp);
+
+    let writer = new jspb.BinaryWriter();
+    writer.writeMessage(Signaling.SESSION_DESCRIPTION, pb_sd, pb.SessionDescription.serializeBinaryToWriter);
+    return writer.getResultBuffer();
+  },
+
+  /**
+   * @param {!ArrayBuffer} data a SignalingEvent
+   * @return {?proto.SessionDescription} its session description, null for any other event
+   */
+  DecodeSessionDescription: (data) => {
+    let reader = new jspb.BinaryReader(data);
+    let pb_sd = null;
+    while (reader.nextField()) {
+      if (reader.isEndGroup()) {
+        break;
+      }
+      if (reader.getFieldNumber() === Signaling.SESSION_DESCRIPTION) {
+        pb_sd = new pb.SessionDescription();
+        reader.readMessage(pb_sd, pb.SessionDescription.deserializeBinaryFromReader);
+      } else {
+        reader.skipField();
+      }
+    }
+    return pb_sd;
+  }
+};
+
+Object.freeze(Signaling);
+
+export { Signaling };
@@ -3,10 +3,14 @@ const express = require("express");
 const http = require("http");
 const socket = require("socket.io");
 const path = require("path");
+const crypto = require("crypto");
 const wrtc = require('wrtc');
 const Peer = require("simple-peer");
 
 const app = express();
+if (process.env.PROD) {
+    app.set("trust proxy", 1); // behind the host's router, which sets the client's address and protocol
+}
 const server = http.createServer(app);
 const io = socket(server);
 
@@ -128,6 +132,133 @@ io.on('connection', socket => {
     });
 });
 
+// Room tokens for the game server, HS256 JWTs signed with the key it was started with, see game/README.md
+const TOKEN_KEY = process.env.ZOOMGAMING_TOKEN_KEY;
+const TOKEN_TTL = 60 * 60; // seconds
+const GAMES = new Set(["TestGame", "SpaceTime", "Broforce"]);
+const ROOM_ID = /^[A-Za-z0-9_-]{1,64}$/;
+const MAX_NAME = 64;
+
+// Browsers are told their client id in a signed, HttpOnly cookie, the only place it is read from
+const SESSION_COOKIE = "zg_session";
+const SESSION_TTL = 365 * 24 * 60 * 60; // seconds
+const SESSIONS_PER_HOUR = 5; // new client ids per address, so that a banned client cannot keep making new ones
+
+const base64url = (buf) => Buffer.from(buf).toString("base64").replace(/=+$/, "").replace(/\+/g, "-").replace(/\//g, "_");
+
+const hmac = (data) => base64url(crypto.createHmac("sha256", TOKEN_KEY).update(data).digest());
+
+const signRoomToken = (claims) => {
+    const header = base64url(JSON.stringify({ alg: "HS256", typ: "JWT" }));
+    const payload = base64url(JSON.stringify(claims));
+    return `${header}.${payload}.${hmac(`${header}.${payload}`)}`;
+};
+
+// The client id of a request's session cookie, null without a valid one
+const sessionClient = (req) => {
+    for (const cookie of (req.headers.cookie || "").split(";")) {
+        const [name, value] = cookie.trim().split("=");
+        if (name !== SESSION_COOKIE || !value) {
+            continue;
+        }
+        const [clientID, signature] = value.split(".");
+        const expected = hmac(`session.${clientID}`);
+        if (signature && signature.length === expected.length && crypto.timingSafeEqual(Buffer.from(signature), Buffer.from(expected))) {
+            return clientID;
+        }
+    }
+    return null;
+};
+
+// Requests made by the web app itself, rather than by another site in the user's browser
+const sameOrigin = (req) => {
+    const site = req.headers["sec-fetch-site"];
+    if (site && site !== "same-origin") {
+        return false;
+    }
+    const origin = req.headers.origin;
+    return !origin || origin === `${req.protocol}://${req.headers.host}`;
+};
+
+// Addresses and when each made its latest new client ids
+const sessionsIssued = new Map();
+setInterval(() => {
+    const hourAgo = Date.now() - 60 * 60 * 1000;
+    for (const [addr, issued] of sessionsIssued) {
+        if (issued.every(at => at <= hourAgo)) {
+            sessionsIssued.delete(addr);
+        }
+    }
+}, 60 * 60 * 1000).unref();
+
+const allowNewSession = (addr) => {
+    const hourAgo = Date.now() - 60 * 60 * 1000;
+    const issued = (sessionsIssued.get(addr) || []).filter(at => at > hourAgo);
+    if (issued.length >= SESSIONS_PER_HOUR) {
+        sessionsIssued.set(addr, issued);
+        return false;
+    }
+    issued.push(Date.now());
+    sessionsIssued.set(addr, issued);
+    return true;
+};
+
+// The web app has no accounts: a browser's identity is its session, which this server issues and signs
+const requireSession = (req, res, next) => {
+    if (!TOKEN_KEY) {
+        return res.status(503).json({ error: "ZOOMGAMING_TOKEN_KEY is not set" });
+    }
+    if (!sameOrigin(req)) {
+        return res.status(403).json({ error: "cross-site request" });
+    }
+    req.clientID = sessionClient(req);
+    next();
+};
+
+// Give the browser a client id, unless it already has one
+app.post("/api/session", requireSession, (req, res) => {
+    if (req.clientID) {
+        return res.json({ client_id: req.clientID });
+    }
+    if (!allowNewSession(req.ip)) {
+        return res.status(429).json({ error: "too many new sessions, try again later" });
+    }
+
+    const clientID = crypto.randomBytes(16).toString("hex");
+    res.cookie(SESSION_COOKIE, `${clientID}.${hmac(`session.${clientID}`)}`, {
+        httpOnly: true,
+        sameSite: "strict",
+        secure: !!process.env.PROD,
+        maxAge: SESSION_TTL * 1000,
+        path: "/api",
+    });
+    res.json({ client_id: clientID });
+});
+
+// A room token for the browser's own client id, which keeps a host who reloads the host
+app.get("/api/token", requireSession, (req, res) => {
+    if (!req.clientID) {
+        return res.status(401).json({ error: "no session, POST /api/session first" });
+    }
+
+    const { room, game, name } = req.query;
+    if (typeof room !== "string" || !ROOM_ID.test(room) || !GAMES.has(game)) {
+        return res.status(400).json({ error: "a room id and a known game are required" });
+    }
+
+    const now = Math.floor(Date.now() / 1000);
+    const token = signRoomToken({
+        room_id: room,
+        game_id: game,
+        name: typeof name === "string" ? name.slice(0, MAX_NAME) : "",
+        sub: req.clientID,
+        iat: now,
+        exp: now + TOKEN_TTL,
+    });
+
+    res.json({ token: token, client_id: req.clientID });
+});
+
 if (process.env.PROD) {
     app.use(express.static(path.join(__dirname, './client/build')));
     app.get('*', (req, res) => {